@@ -0,0 +1,77 @@
+package rnd
+
+import "math"
+
+// wrapAngle returns x modulo 2π, in [0,2π).
+func wrapAngle(x float64) float64 {
+	x = math.Mod(x, 2*math.Pi)
+	if x < 0 {
+		x += 2 * math.Pi
+	}
+	if x >= 2*math.Pi {
+		// Adding 2π to a tiny negative x can round up.
+		return 0
+	}
+	return x
+}
+
+// VonMises returns an angle in [0,2π), drawn from the von Mises distribution
+// with mean direction mu and concentration kappa. The von Mises distribution
+// is the circular analogue of the normal distribution: larger values of kappa
+// concentrate the angle more closely around mu, while kappa = 0 gives a
+// uniform angle. It panics if kappa < 0 or mu or kappa are not finite.
+func VonMises(mu, kappa float64) float64 {
+	if !(kappa >= 0) || math.IsInf(kappa, 0) || math.IsNaN(mu) || math.IsInf(mu, 0) {
+		panic("invalid argument to VonMises")
+	}
+	if kappa < 1e-6 {
+		return wrapAngle(2 * math.Pi * Float64())
+	}
+	// Best and Fisher, "Efficient simulation of the von Mises distribution",
+	// 1979.
+	s := 0.5 / kappa
+	r := s + math.Sqrt(1+s*s)
+	var z float64
+	for {
+		z = math.Cos(math.Pi * Float64())
+		d := z / (r + z)
+		u := Float64()
+		if u < 1-d*d || u <= (1-d)*math.Exp(d) {
+			break
+		}
+	}
+	q := 1 / r
+	f := (q + z) / (1 + q*z)
+	theta := math.Acos(f)
+	if Uint64()&1 == 0 {
+		theta = -theta
+	}
+	return wrapAngle(mu + theta)
+}
+
+// Radians returns a uniform angle in [0,2π).
+func Radians() float64 {
+	return wrapAngle(2 * math.Pi * Float64())
+}
+
+// Degrees returns a uniform angle in [0,360).
+func Degrees() float64 {
+	return min(360*Float64(), math.Nextafter(360, 0))
+}
+
+// AngleBetween returns a uniform angle in [0,2π) on the arc going
+// counterclockwise from the angle from to the angle to, in radians. Both
+// angles are interpreted modulo 2π, so the arc can cross 0: AngleBetween(6, 1)
+// returns angles in [6,2π) or [0,1). If both angles are the same, the arc is
+// the full circle. It panics if from or to is not finite.
+func AngleBetween(from, to float64) float64 {
+	if math.IsNaN(from) || math.IsInf(from, 0) || math.IsNaN(to) || math.IsInf(to, 0) {
+		panic("invalid argument to AngleBetween")
+	}
+	from, to = wrapAngle(from), wrapAngle(to)
+	width := to - from
+	if width <= 0 {
+		width += 2 * math.Pi
+	}
+	return wrapAngle(from + width*Float64())
+}
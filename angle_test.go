@@ -0,0 +1,70 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+// circularMean returns the mean direction and mean resultant length of
+// angles.
+func circularMean(angles []float64) (dir, length float64) {
+	var s, c float64
+	for _, a := range angles {
+		s += math.Sin(a)
+		c += math.Cos(a)
+	}
+	n := float64(len(angles))
+	return wrapAngle(math.Atan2(s, c)), math.Hypot(s, c) / n
+}
+
+func TestVonMises(t *testing.T) {
+	const n = 10000
+	for _, tc := range []struct {
+		mu, kappa float64
+		// length is the expected mean resultant length, I₁(κ)/I₀(κ).
+		length float64
+	}{
+		{0, 0, 0},
+		{1, 1, 0.4464},
+		{6, 4, 0.8635},
+		{-2, 50, 0.9899},
+	} {
+		angles := make([]float64, n)
+		for i := range angles {
+			angles[i] = VonMises(tc.mu, tc.kappa)
+			if angles[i] < 0 || angles[i] >= 2*math.Pi {
+				t.Fatalf("VonMises(%v, %v) = %v, want in [0,2π)", tc.mu, tc.kappa, angles[i])
+			}
+		}
+		dir, length := circularMean(angles)
+		if math.Abs(length-tc.length) > 0.03 {
+			t.Errorf("VonMises(%v, %v): mean resultant length %v, want %v", tc.mu, tc.kappa, length, tc.length)
+		}
+		if d := math.Abs(dir - wrapAngle(tc.mu)); tc.kappa > 0 && math.Min(d, 2*math.Pi-d) > 0.1 {
+			t.Errorf("VonMises(%v, %v): mean direction %v", tc.mu, tc.kappa, dir)
+		}
+	}
+}
+
+func TestAngles(t *testing.T) {
+	for range 10000 {
+		if a := Radians(); a < 0 || a >= 2*math.Pi {
+			t.Fatalf("Radians() = %v", a)
+		}
+		if a := Degrees(); a < 0 || a >= 360 {
+			t.Fatalf("Degrees() = %v", a)
+		}
+		if a := AngleBetween(1, 2); a < 1 || a >= 2 {
+			t.Fatalf("AngleBetween(1, 2) = %v", a)
+		}
+		if a := AngleBetween(6, 1); (a < 6 && a >= 1) || a < 0 || a >= 2*math.Pi {
+			t.Fatalf("AngleBetween(6, 1) = %v", a)
+		}
+		if a := AngleBetween(-1, 1); a >= 1 && a < 2*math.Pi-1 {
+			t.Fatalf("AngleBetween(-1, 1) = %v", a)
+		}
+		if a := AngleBetween(3, 3+2*math.Pi); a < 0 || a >= 2*math.Pi {
+			t.Fatalf("AngleBetween(3, 3+2π) = %v", a)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package rnd
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// Backend identifies the algorithm used to generate pseudo-random numbers.
+// The backend used by the package is chosen at build time, see the package
+// documentation.
+type Backend uint8
+
+const (
+	// ChaCha8 is the ChaCha8 stream cipher, as implemented by math/rand/v2.
+	// It is the slowest, but most robust choice.
+	ChaCha8 Backend = iota
+	// PCG is PCG-DXSM with 128 bits of state, as implemented by
+	// math/rand/v2.
+	PCG
+	// Xoshiro256 is xoshiro256** by Blackman and Vigna. It is the fastest
+	// choice.
+	Xoshiro256
+)
+
+func (b Backend) String() string {
+	switch b {
+	case ChaCha8:
+		return "ChaCha8"
+	case PCG:
+		return "PCG"
+	case Xoshiro256:
+		return "xoshiro256**"
+	default:
+		return "invalid backend"
+	}
+}
+
+// newSource returns a new rand.Source using b, seeded with seed.
+func newSource(b Backend, seed [32]byte) rand.Source {
+	switch b {
+	case ChaCha8:
+		return rand.NewChaCha8(seed)
+	case PCG:
+		return rand.NewPCG(binary.LittleEndian.Uint64(seed[:]), binary.LittleEndian.Uint64(seed[8:]))
+	case Xoshiro256:
+		x := new(xoshiro)
+		x.seed(seed)
+		return x
+	default:
+		panic("invalid backend")
+	}
+}
+
+// xoshiro implements xoshiro256**.
+//
+// See https://prng.di.unimi.it/xoshiro256starstar.c
+type xoshiro struct {
+	s [4]uint64
+}
+
+func (x *xoshiro) seed(seed [32]byte) {
+	// As recommended by the authors, the state is initialized using
+	// SplitMix64, so similar seeds do not lead to similar states.
+	// Each word is chained with the previous one, which keeps the mapping
+	// from seed to state bijective.
+	var h, z uint64
+	for i := range x.s {
+		h = splitmix64(h ^ binary.LittleEndian.Uint64(seed[8*i:]) + 0x9e3779b97f4a7c15)
+		x.s[i] = h
+		z |= h
+	}
+	if z == 0 {
+		// The all-zero state is a fixed point.
+		x.s[0] = 1
+	}
+}
+
+func (x *xoshiro) Uint64() uint64 {
+	s := &x.s
+	result := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return result
+}
+
+// splitmix64 is the output function of SplitMix64.
+func splitmix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (x *xoshiro) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(xoshiroMagic)+32)
+	b = append(b, xoshiroMagic...)
+	for _, s := range x.s {
+		b = binary.LittleEndian.AppendUint64(b, s)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (x *xoshiro) UnmarshalBinary(data []byte) error {
+	if len(data) != len(xoshiroMagic)+32 || string(data[:len(xoshiroMagic)]) != xoshiroMagic {
+		return errors.New("rnd: invalid xoshiro256** encoding")
+	}
+	data = data[len(xoshiroMagic):]
+	for i := range x.s {
+		x.s[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	return nil
+}
+
+const xoshiroMagic = "xoshiro256:"
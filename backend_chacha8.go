@@ -0,0 +1,5 @@
+//go:build !rnd_pcg && !rnd_xoshiro256
+
+package rnd
+
+const defaultBackend = ChaCha8
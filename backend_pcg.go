@@ -0,0 +1,5 @@
+//go:build rnd_pcg
+
+package rnd
+
+const defaultBackend = PCG
@@ -0,0 +1,17 @@
+package rnd
+
+import "testing"
+
+func TestBackends(t *testing.T) {
+	for _, b := range []Backend{ChaCha8, PCG, Xoshiro256} {
+		t.Run(b.String(), func(t *testing.T) {
+			s1, s2 := newSource(b, [32]byte{1}), newSource(b, [32]byte{2})
+			if s1.Uint64() == s2.Uint64() {
+				t.Errorf("different seeds produced the same value")
+			}
+			if newSource(b, [32]byte{}).Uint64() == 0 && newSource(b, [32]byte{}).Uint64() == 0 {
+				t.Errorf("zero seed produced zero values")
+			}
+		})
+	}
+}
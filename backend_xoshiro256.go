@@ -0,0 +1,5 @@
+//go:build rnd_xoshiro256 && !rnd_pcg
+
+package rnd
+
+const defaultBackend = Xoshiro256
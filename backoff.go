@@ -0,0 +1,168 @@
+package rnd
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// The jitter strategies in this file are described in
+// https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+
+// expBackoff returns min(cap, base·2^attempt), without overflowing.
+func expBackoff(base, cap time.Duration, attempt int) time.Duration {
+	d := base
+	for i := 0; i < attempt && d < cap; i++ {
+		if d > cap/2 {
+			return cap
+		}
+		d *= 2
+	}
+	return min(d, cap)
+}
+
+// FullJitter returns a pseudo-random duration in [0,min(cap, base·2^attempt)),
+// for retrying after the given number of failed attempts, starting at 0.
+func FullJitter(base, cap time.Duration, attempt int) time.Duration {
+	d := expBackoff(base, cap, attempt)
+	if d <= 0 {
+		return 0
+	}
+	return Duration(d)
+}
+
+// EqualJitter returns a pseudo-random duration in [d/2,d), where
+// d = min(cap, base·2^attempt), for retrying after the given number of failed
+// attempts, starting at 0.
+func EqualJitter(base, cap time.Duration, attempt int) time.Duration {
+	d := expBackoff(base, cap, attempt)
+	if d <= 1 {
+		return max(d, 0)
+	}
+	return DurationBetween(d/2, d)
+}
+
+// DecorrelatedJitter returns a pseudo-random duration in [base,3·prev),
+// capped at cap, where prev is the duration returned by the previous call.
+// The first call should pass base as prev.
+func DecorrelatedJitter(prev, base, cap time.Duration) time.Duration {
+	hi := time.Duration(math.MaxInt64)
+	if prev < hi/3 {
+		hi = 3 * prev
+	}
+	if hi <= base {
+		return min(base, cap)
+	}
+	return min(DurationBetween(base, hi), cap)
+}
+
+// JitterStrategy selects how a Backoff randomizes its delays.
+type JitterStrategy int
+
+const (
+	// JitterFull uses delays as returned by FullJitter.
+	JitterFull JitterStrategy = iota
+	// JitterEqual uses delays as returned by EqualJitter.
+	JitterEqual
+	// JitterDecorrelated uses delays as returned by DecorrelatedJitter.
+	JitterDecorrelated
+)
+
+// Backoff calculates jittered delays for retry loops. Its methods are safe for
+// concurrent use, but its fields must not be modified after first use.
+type Backoff struct {
+	// Base is the delay before jitter is applied to the first retry.
+	Base time.Duration
+	// Cap is the maximum delay. If it is 0, delays are not capped.
+	Cap time.Duration
+	// Multiplier is the factor by which the delay grows with each attempt. It
+	// defaults to 2. It is ignored by JitterDecorrelated.
+	Multiplier float64
+	// Jitter is the strategy to randomize delays.
+	Jitter JitterStrategy
+
+	mu      sync.Mutex
+	attempt int
+	prev    time.Duration
+}
+
+// Next returns the delay to wait before the next retry.
+func (b *Backoff) Next() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer func() { b.attempt++ }()
+
+	cp := b.Cap
+	if cp == 0 {
+		cp = math.MaxInt64
+	}
+	if b.Jitter == JitterDecorrelated {
+		if b.attempt == 0 {
+			b.prev = b.Base
+		}
+		b.prev = DecorrelatedJitter(b.prev, b.Base, cp)
+		return b.prev
+	}
+
+	m := b.Multiplier
+	if m == 0 {
+		m = 2
+	}
+	d := cp
+	if f := float64(b.Base) * math.Pow(m, float64(b.attempt)); f < float64(cp) {
+		d = time.Duration(f)
+	}
+	switch b.Jitter {
+	case JitterFull:
+		return FullJitter(d, d, 0)
+	case JitterEqual:
+		return EqualJitter(d, d, 0)
+	default:
+		panic("invalid JitterStrategy")
+	}
+}
+
+// Reset resets b to its initial state, after a successful attempt.
+func (b *Backoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempt, b.prev = 0, 0
+}
+
+// Retry calls fn until it returns nil, at most attempts times. Between
+// attempts, it sleeps for a delay as returned by DecorrelatedJitter.
+//
+// Retry returns nil, if a call to fn succeeded. Otherwise, it returns the
+// error returned by the last call. If ctx is done before fn succeeds, Retry
+// returns immediately, with an error wrapping both ctx.Err() and the error of
+// the last call, if any. It panics if attempts < 1.
+func Retry(ctx context.Context, attempts int, base, cap time.Duration, fn func() error) error {
+	if attempts < 1 {
+		panic("invalid attempts for Retry")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var (
+		err   error
+		delay = base
+	)
+	for i := 0; ; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if i == attempts-1 {
+			return err
+		}
+		delay = DecorrelatedJitter(delay, base, cap)
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package rnd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExpBackoff(t *testing.T) {
+	tcs := []struct {
+		base, cap time.Duration
+		attempt   int
+		want      time.Duration
+	}{
+		{time.Second, time.Minute, 0, time.Second},
+		{time.Second, time.Minute, 3, 8 * time.Second},
+		{time.Second, time.Minute, 6, time.Minute},
+		{time.Second, time.Minute, 1000, time.Minute},
+		{time.Second, 1<<63 - 1, 1000, 1<<63 - 1},
+		{time.Minute, time.Second, 0, time.Second},
+	}
+	for _, tc := range tcs {
+		if got := expBackoff(tc.base, tc.cap, tc.attempt); got != tc.want {
+			t.Errorf("expBackoff(%v, %v, %d) = %v, want %v", tc.base, tc.cap, tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestJitterStrategies(t *testing.T) {
+	base, cap := 10*time.Millisecond, time.Second
+	prev := base
+	for attempt := 0; attempt < 20; attempt++ {
+		d := expBackoff(base, cap, attempt)
+		if j := FullJitter(base, cap, attempt); j < 0 || j >= d {
+			t.Errorf("FullJitter(%v, %v, %d) = %v, want in [0,%v)", base, cap, attempt, j, d)
+		}
+		if j := EqualJitter(base, cap, attempt); j < d/2 || j >= d {
+			t.Errorf("EqualJitter(%v, %v, %d) = %v, want in [%v,%v)", base, cap, attempt, j, d/2, d)
+		}
+		j := DecorrelatedJitter(prev, base, cap)
+		if j < base || j > cap || j >= 3*prev {
+			t.Errorf("DecorrelatedJitter(%v, %v, %v) = %v", prev, base, cap, j)
+		}
+		prev = j
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for _, j := range []JitterStrategy{JitterFull, JitterEqual, JitterDecorrelated} {
+		b := &Backoff{Base: 10 * time.Millisecond, Cap: time.Second, Multiplier: 1.5, Jitter: j}
+		for attempt := 0; attempt < 20; attempt++ {
+			if d := b.Next(); d < 0 || d > b.Cap {
+				t.Fatalf("Backoff{Jitter: %d}.Next() = %v, want in [0,%v]", j, d, b.Cap)
+			}
+		}
+		b.Reset()
+		if d := b.Next(); d > 30*time.Millisecond {
+			t.Errorf("Backoff{Jitter: %d}.Next() = %v after Reset", j, d)
+		}
+	}
+}
+
+func TestBackoffNoCap(t *testing.T) {
+	b := &Backoff{Base: time.Second, Jitter: JitterEqual}
+	for attempt := 0; attempt < 100; attempt++ {
+		d := b.Next()
+		if d < 0 {
+			t.Fatalf("Next() = %v for attempt %d", d, attempt)
+		}
+		// Until the delay reaches the maximum duration, it is at least
+		// half of Base·2^attempt.
+		if want := (time.Second << attempt) / 2; attempt < 30 && d < want {
+			t.Fatalf("Next() = %v for attempt %d, want at least %v", d, attempt, want)
+		}
+	}
+	b = &Backoff{Base: time.Second, Jitter: JitterDecorrelated}
+	for attempt := 0; attempt < 100; attempt++ {
+		if d := b.Next(); d < time.Second {
+			t.Fatalf("Backoff{Jitter: JitterDecorrelated}.Next() = %v, want at least 1s", d)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	errFail := errors.New("fail")
+
+	calls := 0
+	err := Retry(ctx, 5, time.Microsecond, time.Millisecond, func() error {
+		if calls++; calls < 3 {
+			return errFail
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Retry = %v after %d calls, want nil after 3 calls", err, calls)
+	}
+
+	calls = 0
+	err = Retry(ctx, 4, time.Microsecond, time.Millisecond, func() error {
+		calls++
+		return errFail
+	})
+	if err != errFail || calls != 4 {
+		t.Errorf("Retry = %v after %d calls, want %v after 4 calls", err, calls, errFail)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	calls = 0
+	err = Retry(ctx, 100, time.Hour, time.Hour, func() error {
+		calls++
+		cancel()
+		return errFail
+	})
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errFail) || calls != 1 {
+		t.Errorf("Retry = %v after %d calls, want to wrap %v and %v after 1 call", err, calls, context.Canceled, errFail)
+	}
+	if err := Retry(ctx, 1, 0, 0, func() error { panic("called") }); err != context.Canceled {
+		t.Errorf("Retry with done context = %v, want %v", err, context.Canceled)
+	}
+}
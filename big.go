@@ -0,0 +1,71 @@
+package rnd
+
+import "math/big"
+
+// BigIntn returns a uniform pseudo-random value in [0,n), like crypto/rand.Int
+// does for keys. It panics if n <= 0.
+func BigIntn(n *big.Int) *big.Int {
+	if n.Sign() <= 0 {
+		panic("invalid argument to BigIntn")
+	}
+	max := new(big.Int).Sub(n, big.NewInt(1))
+	bitLen := max.BitLen()
+	if bitLen == 0 {
+		return new(big.Int)
+	}
+	b := make([]byte, (bitLen+7)/8)
+	// mask clears the excess bits of the most significant byte, so every
+	// candidate is accepted with probability of at least 1/2.
+	mask := byte(1<<(uint(bitLen-1)%8+1) - 1)
+	v := new(big.Int)
+	for {
+		Read(b)
+		b[0] &= mask
+		if v.SetBytes(b).Cmp(n) < 0 {
+			return v
+		}
+	}
+}
+
+// BigFloat returns a uniform pseudo-random value in [0,1), with precision
+// prec. The value is a multiple of 2^-prec. It panics if prec == 0 or
+// prec > big.MaxPrec.
+func BigFloat(prec uint) *big.Float {
+	if prec == 0 || prec > big.MaxPrec {
+		panic("invalid argument to BigFloat")
+	}
+	b := make([]byte, (prec+7)/8)
+	Read(b)
+	// Clear the excess bits, so the value has exactly prec random bits.
+	b[0] &= byte(1<<((prec-1)%8+1) - 1)
+	m := new(big.Float).SetPrec(prec).SetInt(new(big.Int).SetBytes(b))
+	return m.SetMantExp(m, -int(prec))
+}
+
+// Prime returns a pseudo-random probable prime with exactly the given number
+// of bits. It is chosen by generating random odd numbers of that size, until
+// one passes big.Int.ProbablyPrime(20), so every prime of that size can be
+// returned, like crypto/rand.Prime does for keys. It panics if bits < 2.
+func Prime(bits int) *big.Int {
+	if bits < 2 {
+		panic("invalid argument to Prime")
+	}
+	if bits == 2 {
+		// 2 is the only even prime.
+		return big.NewInt(2 + Int63n(2))
+	}
+	b := make([]byte, (bits+7)/8)
+	top := uint(bits-1) % 8
+	v := new(big.Int)
+	for {
+		Read(b)
+		// Clear the excess bits and set the top one, so v has exactly
+		// bits bits.
+		b[0] &= byte(1<<(top+1) - 1)
+		b[0] |= 1 << top
+		b[len(b)-1] |= 1
+		if v.SetBytes(b).ProbablyPrime(20) {
+			return v
+		}
+	}
+}
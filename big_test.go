@@ -0,0 +1,67 @@
+package rnd
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigIntn(t *testing.T) {
+	for _, s := range []string{"1", "2", "255", "256", "257", "1000000000000000000000000000000"} {
+		n, _ := new(big.Int).SetString(s, 10)
+		for i := 0; i < 100; i++ {
+			if v := BigIntn(n); v.Sign() < 0 || v.Cmp(n) >= 0 {
+				t.Fatalf("BigIntn(%v) = %v", n, v)
+			}
+		}
+	}
+	var counts [3]int
+	for i := 0; i < 3000; i++ {
+		counts[BigIntn(big.NewInt(3)).Int64()]++
+	}
+	for i, c := range counts {
+		if c < 850 || c > 1150 {
+			t.Errorf("BigIntn(3) returned %d %d times, want about 1000", i, c)
+		}
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	one := big.NewFloat(1)
+	for _, prec := range []uint{1, 7, 53, 64, 200} {
+		for i := 0; i < 100; i++ {
+			f := BigFloat(prec)
+			if f.Prec() != prec || f.Sign() < 0 || f.Cmp(one) >= 0 {
+				t.Fatalf("BigFloat(%d) = %v with precision %d", prec, f, f.Prec())
+			}
+		}
+	}
+	var sum float64
+	const n = 1000
+	for i := 0; i < n; i++ {
+		v, _ := BigFloat(100).Float64()
+		sum += v
+	}
+	if mean := sum / n; mean < 0.45 || mean > 0.55 {
+		t.Errorf("mean of BigFloat(100) = %v, want 0.5", mean)
+	}
+}
+
+func TestPrime(t *testing.T) {
+	seen := make(map[int64]bool)
+	for range 200 {
+		p := Prime(4)
+		if !p.ProbablyPrime(20) || p.BitLen() != 4 {
+			t.Fatalf("Prime(4) = %v", p)
+		}
+		seen[p.Int64()] = true
+	}
+	// The 4-bit primes are 11 and 13.
+	if len(seen) != 2 {
+		t.Errorf("Prime(4) returned %v, want 11 and 13", seen)
+	}
+	for _, bits := range []int{2, 3, 8, 17, 64, 256} {
+		if p := Prime(bits); !p.ProbablyPrime(20) || p.BitLen() != bits {
+			t.Errorf("Prime(%d) = %v", bits, p)
+		}
+	}
+}
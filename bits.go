@@ -0,0 +1,79 @@
+package rnd
+
+import "math"
+
+// Bits returns a value, of which the low n bits are random and all others
+// are 0. It panics if n > 64.
+func Bits(n uint) uint64 {
+	switch {
+	case n > 64:
+		panic("invalid argument to Bits")
+	case n == 0:
+		return 0
+	}
+	return Uint64() >> (64 - n)
+}
+
+// FillBits sets every bit of dst independently to 1 with probability p. It
+// panics if p is not in [0,1].
+//
+// For p = 0.5, FillBits fills dst with random words. For small p, it skips
+// over geometrically distributed runs of zeros. Otherwise, it combines
+// random words according to the binary expansion of p, truncated to 32 bits,
+// so the probability of each bit deviates from p by less than 2⁻³².
+func FillBits(dst []uint64, p float64) {
+	switch {
+	case !(p >= 0 && p <= 1):
+		panic("invalid probability for FillBits")
+	case p == 0:
+		clear(dst)
+	case p == 1:
+		for i := range dst {
+			dst[i] = ^uint64(0)
+		}
+	case p == 0.5:
+		for i := range dst {
+			dst[i] = Uint64()
+		}
+	case p < 0.125:
+		clear(dst)
+		logq := math.Log1p(-p)
+		gap := func() uint64 {
+			g := math.Floor(math.Log(1-Float64()) / logq)
+			return uint64(min(g, math.MaxInt64))
+		}
+		n := uint64(len(dst)) * 64
+		for pos := gap(); pos < n; pos += 1 + gap() {
+			dst[pos/64] |= 1 << (pos % 64)
+		}
+	default:
+		// If the binary expansion of p is 0.b₁b₂…bₖ, processing the digits
+		// from bₖ to b₁ and computing x = x|r for bᵢ = 1 and x = x&r for
+		// bᵢ = 0, with a fresh random word r for every digit, sets every
+		// bit of x with probability p.
+		q := uint32(min(math.Round(p*(1<<32)), math.MaxUint32))
+		for i := range dst {
+			var x uint64
+			for d := 0; d < 32; d++ {
+				if q&(1<<d) != 0 {
+					x |= Uint64()
+				} else {
+					x &= Uint64()
+				}
+			}
+			dst[i] = x
+		}
+	}
+}
+
+// Bools returns n booleans, each of which is independently true with
+// probability p. See FillBits for details. It panics if p is not in [0,1].
+func Bools(n int, p float64) []bool {
+	words := make([]uint64, (n+63)/64)
+	FillBits(words, p)
+	b := make([]bool, n)
+	for i := range b {
+		b[i] = words[i/64]&(1<<(i%64)) != 0
+	}
+	return b
+}
@@ -0,0 +1,48 @@
+package rnd
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+func TestBits(t *testing.T) {
+	for n := uint(0); n <= 64; n++ {
+		var or uint64
+		for i := 0; i < 100; i++ {
+			v := Bits(n)
+			if n < 64 && v>>n != 0 {
+				t.Fatalf("Bits(%d) = %#x", n, v)
+			}
+			or |= v
+		}
+		if want := uint64(1)<<n - 1; n < 64 && or != want || n == 64 && or != ^uint64(0) {
+			t.Errorf("Bits(%d) only set bits %#x", n, or)
+		}
+	}
+}
+
+func TestFillBits(t *testing.T) {
+	const words = 1000
+	for _, p := range []float64{0, 0.01, 0.1, 0.3, 0.5, 0.9, 1} {
+		dst := make([]uint64, words)
+		FillBits(dst, p)
+		var ones int
+		for _, w := range dst {
+			ones += bits.OnesCount64(w)
+		}
+		n := float64(64 * words)
+		if tol := 5 * math.Sqrt(n*p*(1-p)); math.Abs(float64(ones)-p*n) > tol {
+			t.Errorf("FillBits(%v) set %d of %v bits, want %v±%v", p, ones, n, p*n, tol)
+		}
+	}
+	b := Bools(100, 1)
+	for i, v := range b {
+		if !v {
+			t.Fatalf("Bools(100, 1)[%d] = false", i)
+		}
+	}
+	if len(b) != 100 {
+		t.Errorf("len(Bools(100, 1)) = %d", len(b))
+	}
+}
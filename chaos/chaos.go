@@ -0,0 +1,140 @@
+// Package chaos injects random latency and failures into HTTP clients, for
+// testing the resilience of code using them.
+//
+// The randomness is drawn from the global source of package gonih.org/rnd, so
+// it can be made reproducible for tests using its debug seed.
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"gonih.org/rnd"
+)
+
+// ErrInjected is returned by Transport for requests it fails, if Transport.Err
+// is nil.
+var ErrInjected = errors.New("chaos: injected failure")
+
+// Transport is an http.RoundTripper, which delays requests and fails some of
+// them at random, before passing the others on to Base. It is safe for
+// concurrent use, as long as its fields are not modified.
+//
+// For example, to delay requests by about 100ms and fail 1% of them:
+//
+//	client := &http.Client{Transport: &chaos.Transport{
+//		Latency:   rnd.NormalDist{Mu: 0.1, Sigma: 0.02},
+//		ErrorRate: 0.01,
+//	}}
+type Transport struct {
+	// Base is used to send requests which are not failed. If it is nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// Latency is the distribution of the delay before every request, in
+	// seconds. Negative values are treated as 0. If it is nil, requests are
+	// not delayed. The delay ends early if the request's context is done.
+	Latency rnd.Distribution
+
+	// ErrorRate is the probability of failing a request with Err, instead
+	// of sending it.
+	ErrorRate float64
+
+	// Err is returned for failed requests. If it is nil, ErrInjected is
+	// used.
+	Err error
+
+	// StatusRate is the probability of answering a request which is not
+	// failed with a response with status code Status, instead of sending it.
+	StatusRate float64
+
+	// Status is the status code of injected responses. If it is 0,
+	// http.StatusServiceUnavailable is used.
+	Status int
+}
+
+// RoundTrip implements http.RoundTripper. It panics if t.ErrorRate or
+// t.StatusRate is not in [0,1].
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !validRate(t.ErrorRate) || !validRate(t.StatusRate) {
+		panic("chaos: invalid rate in Transport")
+	}
+	if err := t.delay(req); err != nil {
+		closeBody(req)
+		return nil, err
+	}
+	if rnd.Float64() < t.ErrorRate {
+		closeBody(req)
+		return nil, t.err()
+	}
+	if rnd.Float64() < t.StatusRate {
+		closeBody(req)
+		return t.response(req), nil
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// delay waits for a duration drawn from t.Latency. It returns the error of the
+// request's context, if that is done first.
+func (t *Transport) delay(req *http.Request) error {
+	if t.Latency == nil {
+		return nil
+	}
+	s := t.Latency.Sample(rnd.Global())
+	if !(s > 0) {
+		return nil
+	}
+	d := time.Duration(min(s*float64(time.Second), math.MaxInt64))
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+func validRate(p float64) bool {
+	return p >= 0 && p <= 1
+}
+
+func (t *Transport) err() error {
+	if t.Err == nil {
+		return ErrInjected
+	}
+	return t.Err
+}
+
+// response returns an injected response to req.
+func (t *Transport) response(req *http.Request) *http.Response {
+	code := t.Status
+	if code == 0 {
+		code = http.StatusServiceUnavailable
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode: code,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+}
+
+// closeBody closes the body of a request which is not sent, as required of a
+// RoundTripper.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -0,0 +1,95 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gonih.org/rnd"
+)
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	errTest := errors.New("test")
+	client := &http.Client{Transport: &Transport{
+		ErrorRate:  0.2,
+		Err:        errTest,
+		StatusRate: 0.25,
+		Status:     http.StatusTooManyRequests,
+	}}
+	const n = 1000
+	var fails, injected, ok int
+	for range n {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			if !errors.Is(err, errTest) {
+				t.Fatalf("Get returned unexpected error %v", err)
+			}
+			fails++
+			continue
+		}
+		resp.Body.Close()
+		switch resp.StatusCode {
+		case http.StatusOK:
+			ok++
+		case http.StatusTooManyRequests:
+			injected++
+		default:
+			t.Fatalf("Get returned unexpected status %q", resp.Status)
+		}
+	}
+	// 20% of requests fail and 25% of the remaining ones get an injected
+	// response.
+	for _, c := range []struct {
+		name string
+		got  int
+		want float64
+	}{
+		{"failed", fails, 0.2},
+		{"injected", injected, 0.2},
+		{"ok", ok, 0.6},
+	} {
+		if got := float64(c.got) / n; got < c.want-0.05 || got > c.want+0.05 {
+			t.Errorf("fraction of %s requests is %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestTransportLatency(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{
+		Latency: rnd.UniformDist{Min: 0.02, Max: 0.03},
+	}}
+	start := time.Now()
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Errorf("request took %v, want at least 20ms", d)
+	}
+
+	client = &http.Client{Transport: &Transport{
+		Latency: rnd.UniformDist{Min: 10, Max: 20},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do with expired context returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,37 @@
+package rnd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidArgument is returned (wrapped) by the non-panicking variants of
+// functions, if called with an argument for which the panicking version would
+// panic.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// Int63nErr is like Int63n, but returns an error instead of panicking, if
+// n <= 0.
+func Int63nErr(n int64) (int64, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("rnd: Int63n(%d): %w", n, ErrInvalidArgument)
+	}
+	return Int63n(n), nil
+}
+
+// Int31nErr is like Int31n, but returns an error instead of panicking, if
+// n <= 0.
+func Int31nErr(n int32) (int32, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("rnd: Int31n(%d): %w", n, ErrInvalidArgument)
+	}
+	return Int31n(n), nil
+}
+
+// IntnErr is like Intn, but returns an error instead of panicking, if n <= 0.
+func IntnErr(n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("rnd: Intn(%d): %w", n, ErrInvalidArgument)
+	}
+	return Intn(n), nil
+}
@@ -0,0 +1,27 @@
+package rnd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckedVariants(t *testing.T) {
+	if _, err := IntnErr(0); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("IntnErr(0) = _, %v, want %v", err, ErrInvalidArgument)
+	}
+	if _, err := Int63nErr(-1); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Int63nErr(-1) = _, %v, want %v", err, ErrInvalidArgument)
+	}
+	if _, err := Int31nErr(-1); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Int31nErr(-1) = _, %v, want %v", err, ErrInvalidArgument)
+	}
+	if v, err := IntnErr(1); v != 0 || err != nil {
+		t.Errorf("IntnErr(1) = %d, %v, want 0, <nil>", v, err)
+	}
+	if v, err := Int63nErr(5); v < 0 || v >= 5 || err != nil {
+		t.Errorf("Int63nErr(5) = %d, %v", v, err)
+	}
+	if v, err := Int31nErr(5); v < 0 || v >= 5 || err != nil {
+		t.Errorf("Int31nErr(5) = %d, %v", v, err)
+	}
+}
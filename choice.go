@@ -0,0 +1,240 @@
+package rnd
+
+import (
+	"math"
+	"slices"
+)
+
+// SoftmaxIndex returns a pseudo-random index into scores, where index i is
+// chosen with probability proportional to exp(scores[i]/temperature). Lower
+// temperatures make the choice more greedy, higher temperatures make it more
+// uniform. Scores of -Inf are never chosen. If any score is +Inf, one of
+// those is chosen uniformly.
+//
+// SoftmaxIndex panics if scores is empty, contains NaN or only -Inf, or if
+// temperature <= 0.
+func SoftmaxIndex(scores []float64, temperature float64) int {
+	if !(temperature > 0) {
+		panic("invalid temperature for SoftmaxIndex")
+	}
+	m := math.Inf(-1)
+	for _, s := range scores {
+		if math.IsNaN(s) {
+			panic("invalid score in SoftmaxIndex")
+		}
+		m = max(m, s)
+	}
+	if math.IsInf(m, -1) {
+		panic("no valid score in SoftmaxIndex")
+	}
+	if math.IsInf(m, 1) {
+		n := 0
+		for _, s := range scores {
+			if s == m {
+				n++
+			}
+		}
+		k := Intn(n)
+		for i, s := range scores {
+			if s == m {
+				if k == 0 {
+					return i
+				}
+				k--
+			}
+		}
+	}
+
+	// Subtracting the maximum avoids overflow in exp. The largest weight is
+	// 1, so the total is at least 1.
+	weight := func(s float64) float64 {
+		if math.IsInf(s, -1) {
+			return 0
+		}
+		return math.Exp((s - m) / temperature)
+	}
+	var total float64
+	for _, s := range scores {
+		total += weight(s)
+	}
+	u := Float64() * total
+	last := 0
+	for i, s := range scores {
+		w := weight(s)
+		if w == 0 {
+			continue
+		}
+		if u < w {
+			return i
+		}
+		u -= w
+		last = i
+	}
+	// Only reached due to rounding errors.
+	return last
+}
+
+// PickPair returns two distinct pseudo-random indices in [0,n). Each of the
+// n*(n-1) ordered pairs is equally likely. It panics if n < 2.
+func PickPair(n int) (i, j int) {
+	if n < 2 {
+		panic("invalid argument to PickPair")
+	}
+	i, j = Intn(n), Intn(n-1)
+	if j >= i {
+		j++
+	}
+	return i, j
+}
+
+// TwoChoices returns the less loaded of two distinct pseudo-random indices in
+// [0,n), as reported by load, with ties broken randomly. Assigning work to the
+// less loaded of two random choices ("the power of two choices") balances load
+// exponentially better than a single random choice. If n == 1, it returns 0
+// without calling load. It panics if n < 1.
+func TwoChoices(n int, load func(i int) int) int {
+	return DChoices(n, 2, load)
+}
+
+// DChoices returns the least loaded of min(d,n) distinct pseudo-random indices
+// in [0,n), as reported by load, with ties broken randomly. If only one index
+// is considered, load is not called. It panics if n < 1 or d < 1.
+func DChoices(n, d int, load func(i int) int) int {
+	if n < 1 || d < 1 {
+		panic("invalid argument to DChoices")
+	}
+	if d == 2 && n >= 2 {
+		// Avoid the allocation of PickK for the common case.
+		i, j := PickPair(n)
+		if load(j) < load(i) {
+			return j
+		}
+		return i
+	}
+	idx := PickK(n, min(d, n))
+	if len(idx) == 1 {
+		return idx[0]
+	}
+	best, bestLoad := idx[0], load(idx[0])
+	for _, i := range idx[1:] {
+		if l := load(i); l < bestLoad {
+			best, bestLoad = i, l
+		}
+	}
+	return best
+}
+
+// PickK returns k distinct pseudo-random indices in [0,n), in random order.
+// Unlike Perm, it uses O(k) memory. It panics if k < 0 or k > n.
+func PickK(n, k int) []int {
+	if k < 0 || k > n {
+		panic("invalid argument to PickK")
+	}
+	// Floyd's algorithm. See Bentley and Floyd, "Programming pearls: a
+	// sample of brilliance", 1987.
+	out := make([]int, 0, k)
+	var seen map[int]bool
+	if k > 32 {
+		seen = make(map[int]bool, k)
+	}
+	contains := func(v int) bool {
+		if seen != nil {
+			return seen[v]
+		}
+		for _, w := range out {
+			if w == v {
+				return true
+			}
+		}
+		return false
+	}
+	for j := n - k; j < n; j++ {
+		v := Intn(j + 1)
+		if contains(v) {
+			v = j
+		}
+		out = append(out, v)
+		if seen != nil {
+			seen[v] = true
+		}
+	}
+	// Floyd's algorithm produces a uniform set, but not a uniform order.
+	Shuffle(out)
+	return out
+}
+
+// SampleOrdered returns k distinct pseudo-random elements of s, preserving
+// their relative order in s. Every subset of size k is equally likely. It is
+// meant for downsampling ordered data, like logs or time series. It panics if
+// k < 0 or k > len(s).
+func SampleOrdered[T any](s []T, k int) []T {
+	if k < 0 || k > len(s) {
+		panic("invalid argument to SampleOrdered")
+	}
+	out := make([]T, 0, k)
+	if k > len(s)/8 {
+		// Selection sampling (Knuth's Algorithm S): select every element with
+		// probability needed/remaining.
+		for i := 0; len(out) < k; i++ {
+			if Intn(len(s)-i) < k-len(out) {
+				out = append(out, s[i])
+			}
+		}
+		return out
+	}
+	// For small samples, it is cheaper to pick the indices and sort them.
+	idx := PickK(len(s), k)
+	slices.Sort(idx)
+	for _, i := range idx {
+		out = append(out, s[i])
+	}
+	return out
+}
+
+// OneOf calls one of fns, chosen uniformly. It panics if fns is empty.
+func OneOf(fns ...func()) {
+	if len(fns) == 0 {
+		panic("no functions passed to OneOf")
+	}
+	fns[Intn(len(fns))]()
+}
+
+// OneOfWeighted calls one of fns, chosen with probability proportional to
+// weights. It panics if len(weights) != len(fns), any weight is negative, NaN
+// or infinite or all weights are 0.
+func OneOfWeighted(weights []float64, fns ...func()) {
+	if len(weights) != len(fns) {
+		panic("mismatched lengths in OneOfWeighted")
+	}
+	fns[weightedIndex(weights)]()
+}
+
+// weightedIndex returns a pseudo-random index into weights, chosen with
+// probability proportional to the weights. It panics if any weight is
+// negative, NaN or infinite, or all weights are 0.
+func weightedIndex(weights []float64) int {
+	var total float64
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid weight")
+		}
+		total += w
+	}
+	if !(total > 0) {
+		panic("zero total weight")
+	}
+	u := Float64() * total
+	last := 0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		if u < w {
+			return i
+		}
+		u -= w
+		last = i
+	}
+	// Only reached due to rounding errors.
+	return last
+}
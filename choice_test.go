@@ -0,0 +1,150 @@
+package rnd
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSoftmaxIndex(t *testing.T) {
+	scores := []float64{1000, 1000 + math.Log(3), math.Inf(-1)}
+	var counts [3]int
+	const N = 10000
+	for i := 0; i < N; i++ {
+		counts[SoftmaxIndex(scores, 1)]++
+	}
+	if counts[2] != 0 {
+		t.Errorf("SoftmaxIndex chose index with score -Inf %d times", counts[2])
+	}
+	if f := float64(counts[1]) / N; math.Abs(f-0.75) > 0.03 {
+		t.Errorf("SoftmaxIndex chose index 1 with frequency %v, want 0.75", f)
+	}
+
+	for i := 0; i < 100; i++ {
+		if got := SoftmaxIndex([]float64{0, 1, 2}, 1e-9); got != 2 {
+			t.Fatalf("SoftmaxIndex with low temperature = %d, want 2", got)
+		}
+		if got := SoftmaxIndex([]float64{0, math.Inf(1), 2}, 1); got != 1 {
+			t.Fatalf("SoftmaxIndex with score +Inf = %d, want 1", got)
+		}
+	}
+}
+
+func TestPickPair(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		a, b := PickPair(3)
+		if a == b || a < 0 || a >= 3 || b < 0 || b >= 3 {
+			t.Fatalf("PickPair(3) = %d, %d", a, b)
+		}
+	}
+}
+
+func TestTwoChoices(t *testing.T) {
+	// Balls into bins: with two choices, the maximum load is much smaller
+	// than with one choice.
+	const n = 1000
+	for _, d := range []int{1, 2, 3} {
+		loads := make([]int, n)
+		for range 10 * n {
+			var i int
+			if d == 2 {
+				i = TwoChoices(n, func(i int) int { return loads[i] })
+			} else {
+				i = DChoices(n, d, func(i int) int { return loads[i] })
+			}
+			loads[i]++
+		}
+		maxLoad := slices.Max(loads)
+		if (d == 1 && maxLoad < 16) || (d > 1 && maxLoad > 14) {
+			t.Errorf("with %d choices, the maximum load of %d bins is %d", d, n, maxLoad)
+		}
+	}
+	if i := TwoChoices(1, nil); i != 0 {
+		t.Errorf("TwoChoices(1, nil) = %d, want 0", i)
+	}
+	if i := DChoices(3, 5, func(i int) int { return -i }); i != 2 {
+		t.Errorf("DChoices(3, 5, …) = %d, want the least loaded index 2", i)
+	}
+}
+
+func TestPickK(t *testing.T) {
+	for _, tc := range []struct{ n, k int }{{0, 0}, {10, 0}, {10, 3}, {10, 10}, {1000, 100}} {
+		got := PickK(tc.n, tc.k)
+		if len(got) != tc.k {
+			t.Fatalf("len(PickK(%d, %d)) = %d", tc.n, tc.k, len(got))
+		}
+		seen := make(map[int]bool)
+		for _, v := range got {
+			if v < 0 || v >= tc.n || seen[v] {
+				t.Fatalf("PickK(%d, %d) = %v", tc.n, tc.k, got)
+			}
+			seen[v] = true
+		}
+	}
+}
+
+func TestSampleOrdered(t *testing.T) {
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = i
+	}
+	for _, k := range []int{0, 1, 10, 500, 1000} {
+		got := SampleOrdered(s, k)
+		if len(got) != k {
+			t.Fatalf("len(SampleOrdered(s, %d)) = %d", k, len(got))
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i-1] >= got[i] {
+				t.Fatalf("SampleOrdered(s, %d) = %v, want increasing", k, got)
+			}
+		}
+	}
+	// Both algorithms must select every element with probability k/n.
+	for _, k := range []int{5, 50} {
+		var counts [100]int
+		for range 20000 {
+			for _, v := range SampleOrdered(s[:100], k) {
+				counts[v]++
+			}
+		}
+		for i, c := range counts {
+			if want := 200 * k; math.Abs(float64(c-want)) > 0.15*float64(want) {
+				t.Errorf("SampleOrdered(s[:100], %d) selected %d %d times, want %d", k, i, c, want)
+			}
+		}
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	var counts [3]int
+	for i := 0; i < 300; i++ {
+		OneOf(func() { counts[0]++ }, func() { counts[1]++ }, func() { counts[2]++ })
+	}
+	for i, c := range counts {
+		if c == 0 {
+			t.Errorf("OneOf never called function %d", i)
+		}
+	}
+
+	counts = [3]int{}
+	for i := 0; i < 300; i++ {
+		OneOfWeighted([]float64{1, 0, 1}, func() { counts[0]++ }, func() { counts[1]++ }, func() { counts[2]++ })
+	}
+	if counts[0] == 0 || counts[1] != 0 || counts[2] == 0 {
+		t.Errorf("OneOfWeighted called functions %v times, with weights [1 0 1]", counts)
+	}
+}
+
+func TestOneOfWeightedPanics(t *testing.T) {
+	// The weights are validated like those of NewAliasTable.
+	for _, w := range [][]float64{{0, 0}, {1, -1}, {math.NaN(), 1}, {1, math.Inf(1)}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("OneOfWeighted(%v) did not panic", w)
+				}
+			}()
+			OneOfWeighted(w, func() {}, func() {})
+		}()
+	}
+}
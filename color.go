@@ -0,0 +1,43 @@
+package rnd
+
+import "math"
+
+// Color returns a uniformly chosen 24-bit RGB color.
+func Color() (r, g, b uint8) {
+	v := Uint32()
+	return uint8(v >> 16), uint8(v >> 8), uint8(v)
+}
+
+// PleasantColor returns an RGB color with a uniformly chosen hue and fixed,
+// moderate saturation and lightness. Such colors are easy to tell apart by
+// hue and look good on both light and dark backgrounds.
+func PleasantColor() (r, g, b uint8) {
+	return hslToRGB(360*Float64(), 0.65, 0.55)
+}
+
+// hslToRGB converts a color from HSL to RGB. h is in degrees, in [0,360), s
+// and l are in [0,1].
+func hslToRGB(h, s, l float64) (r, g, b uint8) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var rf, gf, bf float64
+	switch {
+	case h < 60:
+		rf, gf, bf = c, x, 0
+	case h < 120:
+		rf, gf, bf = x, c, 0
+	case h < 180:
+		rf, gf, bf = 0, c, x
+	case h < 240:
+		rf, gf, bf = 0, x, c
+	case h < 300:
+		rf, gf, bf = x, 0, c
+	default:
+		rf, gf, bf = c, 0, x
+	}
+	m := l - c/2
+	conv := func(v float64) uint8 {
+		return uint8(math.Round(255 * (v + m)))
+	}
+	return conv(rf), conv(gf), conv(bf)
+}
@@ -0,0 +1,52 @@
+package rnd
+
+import "testing"
+
+func TestColor(t *testing.T) {
+	var seen [3]map[uint8]bool
+	for i := range seen {
+		seen[i] = make(map[uint8]bool)
+	}
+	for i := 0; i < 1000; i++ {
+		r, g, b := Color()
+		seen[0][r], seen[1][g], seen[2][b] = true, true, true
+	}
+	for i, s := range seen {
+		if len(s) < 200 {
+			t.Errorf("component %d of Color took only %d distinct values", i, len(s))
+		}
+	}
+}
+
+func TestHSLToRGB(t *testing.T) {
+	tcs := []struct {
+		h, s, l float64
+		r, g, b uint8
+	}{
+		{0, 1, 0.5, 255, 0, 0},
+		{120, 1, 0.5, 0, 255, 0},
+		{240, 1, 0.5, 0, 0, 255},
+		{60, 1, 0.5, 255, 255, 0},
+		{0, 0, 1, 255, 255, 255},
+		{0, 0, 0, 0, 0, 0},
+		{210, 0.65, 0.55, 66, 140, 215},
+	}
+	for _, tc := range tcs {
+		if r, g, b := hslToRGB(tc.h, tc.s, tc.l); r != tc.r || g != tc.g || b != tc.b {
+			t.Errorf("hslToRGB(%v, %v, %v) = %d, %d, %d, want %d, %d, %d", tc.h, tc.s, tc.l, r, g, b, tc.r, tc.g, tc.b)
+		}
+	}
+}
+
+func TestPleasantColor(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		r, g, b := PleasantColor()
+		// With saturation 0.65 and lightness 0.55, the components are in
+		// [0.2075,0.8925].
+		for _, v := range []uint8{r, g, b} {
+			if v < 52 || v > 228 {
+				t.Fatalf("PleasantColor() = %d, %d, %d", r, g, b)
+			}
+		}
+	}
+}
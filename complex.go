@@ -0,0 +1,23 @@
+package rnd
+
+import "math"
+
+// Complex128 returns a complex number uniformly distributed in the unit
+// square, that is with real and imaginary parts in [0,1).
+func Complex128() complex128 {
+	return complex(Float64(), Float64())
+}
+
+// OnUnitCircle returns a complex number uniformly distributed on the unit
+// circle, that is with absolute value 1 and a uniform phase.
+func OnUnitCircle() complex128 {
+	return complex(UnitVector2())
+}
+
+// ComplexNormal returns a standard circularly-symmetric complex normal
+// number: real and imaginary parts are independent and normally distributed
+// with mean 0 and variance 1/2, so the expected squared absolute value is 1.
+func ComplexNormal() complex128 {
+	x, y := NormPair()
+	return complex(x/math.Sqrt2, y/math.Sqrt2)
+}
@@ -0,0 +1,25 @@
+package rnd
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestComplex(t *testing.T) {
+	const n = 10000
+	var sq float64
+	for range n {
+		if z := Complex128(); real(z) < 0 || real(z) >= 1 || imag(z) < 0 || imag(z) >= 1 {
+			t.Fatalf("Complex128() = %v", z)
+		}
+		if z := OnUnitCircle(); math.Abs(cmplx.Abs(z)-1) > 1e-12 {
+			t.Fatalf("|OnUnitCircle()| = %v", cmplx.Abs(z))
+		}
+		z := ComplexNormal()
+		sq += real(z)*real(z) + imag(z)*imag(z)
+	}
+	if m := sq / n; math.Abs(m-1) > 0.05 {
+		t.Errorf("mean of |ComplexNormal()|² = %v, want 1", m)
+	}
+}
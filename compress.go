@@ -0,0 +1,49 @@
+package rnd
+
+import "math"
+
+// compressBlock is the granularity in which CompressibleBytes mixes random and
+// repeated data. It is small enough for the window of any common compression
+// algorithm.
+const compressBlock = 4096
+
+// CompressibleBytes returns n pseudo-random bytes, which general purpose
+// compression algorithms like DEFLATE, zstd or LZ4 shrink by about the
+// fraction ratio. For example, with a ratio of 0.8, the data compresses to
+// about 20% of its size. A ratio of 0 gives incompressible data and a ratio
+// of 1 gives data consisting of runs of a single byte.
+//
+// The data consists of random spans, interspersed with runs of a repeated
+// byte, so compressors with a small window still achieve the target ratio.
+// Compressors add some overhead, so the actual ratio is slightly smaller,
+// especially for small n or a ratio close to 1.
+//
+// CompressibleBytes panics if n < 0 or ratio is not in [0,1].
+func CompressibleBytes(n int, ratio float64) []byte {
+	if n < 0 || !(ratio >= 0 && ratio <= 1) {
+		panic("invalid argument to CompressibleBytes")
+	}
+	b := make([]byte, n)
+	var (
+		// want is the number of random bytes the output should have, done
+		// the number written so far.
+		want = (1 - ratio) * float64(n)
+		done int
+	)
+	for off := 0; off < n; off += compressBlock {
+		blk := b[off:min(off+compressBlock, n)]
+		// Distribute the random bytes proportionally, keeping the total
+		// exact.
+		k := int(math.Round(want*float64(off+len(blk))/float64(n))) - done
+		k = min(max(k, 0), len(blk))
+		Read(blk[:k])
+		done += k
+		if k < len(blk) {
+			c := byte(Uint32())
+			for i := k; i < len(blk); i++ {
+				blk[i] = c
+			}
+		}
+	}
+	return b
+}
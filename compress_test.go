@@ -0,0 +1,31 @@
+package rnd
+
+import (
+	"bytes"
+	"compress/flate"
+	"math"
+	"testing"
+)
+
+func TestCompressibleBytes(t *testing.T) {
+	const n = 1 << 20
+	for _, ratio := range []float64{0, 0.25, 0.5, 0.8, 0.95} {
+		b := CompressibleBytes(n, ratio)
+		if len(b) != n {
+			t.Fatalf("len(CompressibleBytes(%d, %v)) = %d", n, ratio, len(b))
+		}
+		var buf bytes.Buffer
+		w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+		w.Write(b)
+		w.Close()
+		if got := 1 - float64(buf.Len())/n; math.Abs(got-ratio) > 0.02 {
+			t.Errorf("CompressibleBytes(%d, %v) compresses by %v", n, ratio, got)
+		}
+	}
+	if b := CompressibleBytes(0, 0.5); len(b) != 0 {
+		t.Errorf("CompressibleBytes(0, 0.5) = %v, want empty", b)
+	}
+	if b := CompressibleBytes(100, 1); !bytes.Equal(b, bytes.Repeat(b[:1], 100)) {
+		t.Errorf("CompressibleBytes(100, 1) = %v, want constant", b)
+	}
+}
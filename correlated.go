@@ -0,0 +1,97 @@
+package rnd
+
+import "math"
+
+// CorrelatedNormals returns len(corr) sequences of n standard normal
+// variates each, such that out[i][k] and out[j][k] have correlation
+// corr[i][j]. Different positions k are independent.
+//
+// CorrelatedNormals panics if n < 0 or if corr is not a valid correlation
+// matrix, that is a symmetric, positive semi-definite matrix with ones on
+// the diagonal.
+func CorrelatedNormals(n int, corr [][]float64) [][]float64 {
+	if n < 0 {
+		panic("invalid argument to CorrelatedNormals")
+	}
+	return correlatedNormals(n, corr, "invalid correlation matrix for CorrelatedNormals")
+}
+
+// CorrelatedUniforms returns len(corr) sequences of n pseudo-random numbers
+// in [0,1) each, such that out[i][k] and out[j][k] have (Pearson)
+// correlation corr[i][j]. Different positions k are independent.
+//
+// The values are obtained by transforming correlated normal variates with the
+// normal CDF (a Gaussian copula). That transformation reduces correlations, so
+// CorrelatedUniforms compensates by using the normal correlation
+// 2·sin(π·ρ/6) for a target correlation of ρ.
+//
+// CorrelatedUniforms panics if n < 0, if corr is not a valid correlation
+// matrix or if the compensated matrix is not positive semi-definite, which can
+// happen for extreme, but valid, correlation matrices.
+func CorrelatedUniforms(n int, corr [][]float64) [][]float64 {
+	if n < 0 {
+		panic("invalid argument to CorrelatedUniforms")
+	}
+	const msg = "invalid correlation matrix for CorrelatedUniforms"
+	if !isCorrelation(corr) {
+		panic(msg)
+	}
+	adj := make([][]float64, len(corr))
+	for i, row := range corr {
+		adj[i] = make([]float64, len(row))
+		for j, c := range row {
+			adj[i][j] = 2 * math.Sin(math.Pi*c/6)
+		}
+		adj[i][i] = 1 // avoid rounding errors
+	}
+	out := correlatedNormals(n, adj, msg)
+	for _, s := range out {
+		for k, x := range s {
+			s[k] = min(0.5*math.Erfc(-x/math.Sqrt2), math.Nextafter(1, 0))
+		}
+	}
+	return out
+}
+
+func correlatedNormals(n int, corr [][]float64, msg string) [][]float64 {
+	if !isCorrelation(corr) {
+		panic(msg)
+	}
+	l, ok := cholesky(corr)
+	if !ok {
+		panic(msg)
+	}
+	d := len(corr)
+	out := make([][]float64, d)
+	for i := range out {
+		out[i] = make([]float64, n)
+	}
+	z := make([]float64, d)
+	for k := range n {
+		FillNormFloat64(z)
+		for i, row := range l {
+			var v float64
+			for j, c := range row {
+				v += c * z[j]
+			}
+			out[i][k] = v
+		}
+	}
+	return out
+}
+
+// isCorrelation reports whether m is square, with ones on the diagonal and
+// all entries in [-1,1].
+func isCorrelation(m [][]float64) bool {
+	for i, row := range m {
+		if len(row) != len(m) || row[i] != 1 {
+			return false
+		}
+		for _, c := range row {
+			if !(c >= -1 && c <= 1) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+// pearson returns the correlation coefficient of a and b.
+func pearson(a, b []float64) float64 {
+	var ma, mb float64
+	for i := range a {
+		ma += a[i]
+		mb += b[i]
+	}
+	ma /= float64(len(a))
+	mb /= float64(len(b))
+	var sab, saa, sbb float64
+	for i := range a {
+		sab += (a[i] - ma) * (b[i] - mb)
+		saa += (a[i] - ma) * (a[i] - ma)
+		sbb += (b[i] - mb) * (b[i] - mb)
+	}
+	return sab / math.Sqrt(saa*sbb)
+}
+
+func TestCorrelated(t *testing.T) {
+	const n = 20000
+	corr := [][]float64{
+		{1, 0.8, -0.3},
+		{0.8, 1, 0},
+		{-0.3, 0, 1},
+	}
+	for _, tc := range []struct {
+		name string
+		f    func(int, [][]float64) [][]float64
+	}{
+		{"CorrelatedNormals", CorrelatedNormals},
+		{"CorrelatedUniforms", CorrelatedUniforms},
+	} {
+		out := tc.f(n, corr)
+		if len(out) != 3 {
+			t.Fatalf("len(%s(…)) = %d, want 3", tc.name, len(out))
+		}
+		for i := range out {
+			if len(out[i]) != n {
+				t.Fatalf("len(%s(…)[%d]) = %d, want %d", tc.name, i, len(out[i]), n)
+			}
+			for j := range i {
+				if c := pearson(out[i], out[j]); math.Abs(c-corr[i][j]) > 0.03 {
+					t.Errorf("%s: correlation of %d and %d = %v, want %v", tc.name, i, j, c, corr[i][j])
+				}
+			}
+		}
+	}
+	for _, s := range CorrelatedUniforms(n, corr) {
+		for _, v := range s {
+			if v < 0 || v >= 1 {
+				t.Fatalf("CorrelatedUniforms returned %v, want in [0,1)", v)
+			}
+		}
+	}
+}
+
+func TestCorrelatedPanics(t *testing.T) {
+	for _, corr := range [][][]float64{
+		{{1, 2}, {2, 1}},
+		{{2, 0}, {0, 1}},
+		{{1, 0.5}, {0, 1}},
+		{{1, 0.9, 0.9}, {0.9, 1, -0.9}, {0.9, -0.9, 1}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("CorrelatedNormals(1, %v) did not panic", corr)
+				}
+			}()
+			CorrelatedNormals(1, corr)
+		}()
+	}
+}
@@ -0,0 +1,51 @@
+package rnd
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// counterShards is the number of shards of a shardedCounter.
+const counterShards = 32
+
+// shardedCounter is a counter, which is cheap to increment concurrently. Its
+// value is spread over shards on separate cache lines, so goroutines on
+// different CPUs do not contend for the same cache line. Reading the total is
+// comparatively expensive.
+type shardedCounter struct {
+	shards [counterShards]struct {
+		n atomic.Uint64
+		// Pad to 128 bytes, as some CPUs prefetch pairs of cache lines.
+		_ [120]byte
+	}
+}
+
+// shard returns the index of a shard to use. It uses the runtime's random
+// number generator, which has per-thread state and does not synchronize.
+func shard() int {
+	return int(rand.Uint32() % counterShards)
+}
+
+// add adds n to shard i and returns the new value of that shard.
+func (c *shardedCounter) add(i int, n uint64) uint64 {
+	return c.shards[i].n.Add(n)
+}
+
+// load returns the total of c. It is not atomic with respect to concurrent
+// calls to add.
+func (c *shardedCounter) load() uint64 {
+	var n uint64
+	for i := range c.shards {
+		n += c.shards[i].n.Load()
+	}
+	return n
+}
+
+// reset sets all shards of c to 0 and returns the total they contained.
+func (c *shardedCounter) reset() uint64 {
+	var n uint64
+	for i := range c.shards {
+		n += c.shards[i].n.Swap(0)
+	}
+	return n
+}
@@ -0,0 +1,38 @@
+package rnd
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestShardedCounter(t *testing.T) {
+	var c shardedCounter
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				c.add(shard(), 2)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := c.load(); got != 16000 {
+		t.Errorf("load() = %d, want 16000", got)
+	}
+	if got := c.reset(); got != 16000 {
+		t.Errorf("reset() = %d, want 16000", got)
+	}
+	if got := c.load(); got != 0 {
+		t.Errorf("load() after reset = %d, want 0", got)
+	}
+}
+
+func BenchmarkReseedParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			reseed(1)
+		}
+	})
+}
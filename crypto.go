@@ -0,0 +1,37 @@
+package rnd
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	mrand "math/rand/v2"
+)
+
+// cryptoSource is a rand.Source using crypto/rand.
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	rand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// secure generates values using crypto/rand. As cryptoSource is stateless, it
+// is safe for concurrent use.
+var secure = mrand.New(cryptoSource{})
+
+// SecureShuffle randomizes the order of elements of s, like Shuffle. Unlike
+// Shuffle, it uses crypto/rand, so the order is unpredictable, even to an
+// attacker observing other values of this package. It is much slower than
+// Shuffle.
+func SecureShuffle[T any](s []T) {
+	secure.Shuffle(len(s), func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
+
+// SecurePerm returns a random permutation of the integers [0,n), like Perm.
+// Unlike Perm, it uses crypto/rand, so the permutation is unpredictable. It is
+// much slower than Perm.
+func SecurePerm(n int) []int {
+	return secure.Perm(n)
+}
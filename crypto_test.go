@@ -0,0 +1,37 @@
+package rnd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSecureShuffle(t *testing.T) {
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i
+	}
+	SecureShuffle(s)
+	if slices.IsSorted(s) {
+		t.Error("SecureShuffle did not change the order")
+	}
+	slices.Sort(s)
+	for i, v := range s {
+		if v != i {
+			t.Fatalf("SecureShuffle changed the elements: %v", s)
+		}
+	}
+	SecureShuffle[int](nil)
+}
+
+func TestSecurePerm(t *testing.T) {
+	p := SecurePerm(100)
+	if len(p) != 100 {
+		t.Fatalf("len(SecurePerm(100)) = %d", len(p))
+	}
+	slices.Sort(p)
+	for i, v := range p {
+		if v != i {
+			t.Fatalf("SecurePerm(100) is not a permutation")
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package rnd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strconv"
+	"sync/atomic"
+)
+
+// debugSeedEnv is the environment variable checked by EnableDebugSeeding.
+const debugSeedEnv = "RND_DEBUG_SEED"
+
+// debugSeeded is set, if the global source has been seeded by
+// EnableDebugSeeding. It disables re-seeding.
+var debugSeeded atomic.Bool
+
+// EnableDebugSeeding allows making the global source reproducible, for
+// debugging. If the environment variable RND_DEBUG_SEED is set to an unsigned
+// integer, the global source is seeded with it and is never re-seeded. If the
+// variable is not set, EnableDebugSeeding does nothing.
+//
+// Only a program can opt into this, by calling EnableDebugSeeding, usually
+// at the start of main. Libraries must not call it. Note that the values
+// returned by the package-level functions are only reproducible, if the
+// program calls them in a deterministic order. Functions which usually draw
+// from pooled generators, like WithRand, FillDist or Cycle, then seed a new
+// generator from the global source for every call, so they are reproducible
+// as well.
+func EnableDebugSeeding() error {
+	s, ok := os.LookupEnv(debugSeedEnv)
+	if !ok {
+		return nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("rnd: invalid %s: %w", debugSeedEnv, err)
+	}
+	var seed [32]byte
+	binary.LittleEndian.PutUint64(seed[:], v)
+	debugSeeded.Store(true)
+	src.seed(seed)
+	notifyReseed(ReasonDebug)
+	return nil
+}
@@ -0,0 +1,27 @@
+package rnd
+
+import "testing"
+
+func TestEnableDebugSeeding(t *testing.T) {
+	defer func() {
+		debugSeeded.Store(false)
+		src.seed(newSeed())
+	}()
+
+	t.Setenv(debugSeedEnv, "x")
+	if err := EnableDebugSeeding(); err == nil {
+		t.Errorf("EnableDebugSeeding() with %s=x succeeded", debugSeedEnv)
+	}
+
+	t.Setenv(debugSeedEnv, "42")
+	if err := EnableDebugSeeding(); err != nil {
+		t.Fatal(err)
+	}
+	x := Uint64()
+	if err := EnableDebugSeeding(); err != nil {
+		t.Fatal(err)
+	}
+	if y := Uint64(); x != y {
+		t.Errorf("Uint64() = %d after re-enabling debug seeding, want %d", y, x)
+	}
+}
@@ -0,0 +1,112 @@
+package rnd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxDice is the maximum number of dice accepted by ParseDice.
+const maxDice = 1000
+
+// Dice describes a roll of dice in standard dice notation.
+type Dice struct {
+	// N is the number of dice to roll.
+	N int
+	// Sides is the number of sides of each die.
+	Sides int
+	// DropLowest and DropHighest are the number of lowest and highest dice
+	// to ignore.
+	DropLowest, DropHighest int
+	// Modifier is added to the sum of the remaining dice.
+	Modifier int
+}
+
+// ParseDice parses a roll in dice notation. The notation consists of an
+// optional number of dice N (defaulting to 1), a "d", the number of sides S
+// and an optional modifier "+M" or "-M". It can be followed by " drop lowest"
+// or " drop highest", to ignore one die. For example "3d6+2", "d20" or
+// "4d6 drop lowest".
+func ParseDice(spec string) (Dice, error) {
+	s := strings.ToLower(strings.TrimSpace(spec))
+	var d Dice
+	if rest, ok := strings.CutSuffix(s, " drop lowest"); ok {
+		s, d.DropLowest = strings.TrimSpace(rest), 1
+	} else if rest, ok := strings.CutSuffix(s, " drop highest"); ok {
+		s, d.DropHighest = strings.TrimSpace(rest), 1
+	}
+	n, s, ok := strings.Cut(s, "d")
+	if !ok {
+		return Dice{}, fmt.Errorf("rnd: invalid dice %q", spec)
+	}
+	if i := strings.IndexAny(s, "+-"); i >= 0 {
+		m, err := strconv.Atoi(s[i:])
+		if err != nil {
+			return Dice{}, fmt.Errorf("rnd: invalid modifier in dice %q", spec)
+		}
+		s, d.Modifier = s[:i], m
+	}
+	d.N = 1
+	if n != "" {
+		v, err := strconv.Atoi(n)
+		if err != nil || v < 1 || v > maxDice {
+			return Dice{}, fmt.Errorf("rnd: invalid number of dice in %q", spec)
+		}
+		d.N = v
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 1 {
+		return Dice{}, fmt.Errorf("rnd: invalid number of sides in dice %q", spec)
+	}
+	d.Sides = v
+	if d.DropLowest+d.DropHighest >= d.N {
+		return Dice{}, fmt.Errorf("rnd: dropping all dice in %q", spec)
+	}
+	return d, nil
+}
+
+// Roll rolls d and returns the result. It panics if d is invalid.
+func (d Dice) Roll() int {
+	if d.N < 1 || d.Sides < 1 || d.DropLowest < 0 || d.DropHighest < 0 || d.DropLowest+d.DropHighest >= d.N {
+		panic("invalid Dice")
+	}
+	rolls := make([]int, d.N)
+	for i := range rolls {
+		rolls[i] = 1 + Intn(d.Sides)
+	}
+	if d.DropLowest+d.DropHighest > 0 {
+		slices.Sort(rolls)
+		rolls = rolls[d.DropLowest : d.N-d.DropHighest]
+	}
+	sum := d.Modifier
+	for _, r := range rolls {
+		sum += r
+	}
+	return sum
+}
+
+// String returns d in dice notation, as accepted by ParseDice, if possible.
+func (d Dice) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%dd%d", d.N, d.Sides)
+	if d.Modifier != 0 {
+		fmt.Fprintf(&sb, "%+d", d.Modifier)
+	}
+	for range d.DropLowest {
+		sb.WriteString(" drop lowest")
+	}
+	for range d.DropHighest {
+		sb.WriteString(" drop highest")
+	}
+	return sb.String()
+}
+
+// Roll parses spec using ParseDice and rolls the result.
+func Roll(spec string) (int, error) {
+	d, err := ParseDice(spec)
+	if err != nil {
+		return 0, err
+	}
+	return d.Roll(), nil
+}
@@ -0,0 +1,55 @@
+package rnd
+
+import "testing"
+
+func TestParseDice(t *testing.T) {
+	tcs := []struct {
+		spec string
+		want Dice
+	}{
+		{"3d6+2", Dice{N: 3, Sides: 6, Modifier: 2}},
+		{"d20", Dice{N: 1, Sides: 20}},
+		{"2D8-1", Dice{N: 2, Sides: 8, Modifier: -1}},
+		{"4d6 drop lowest", Dice{N: 4, Sides: 6, DropLowest: 1}},
+		{" 2d20 drop highest ", Dice{N: 2, Sides: 20, DropHighest: 1}},
+	}
+	for _, tc := range tcs {
+		got, err := ParseDice(tc.spec)
+		if err != nil || got != tc.want {
+			t.Errorf("ParseDice(%q) = %v, %v, want %v, <nil>", tc.spec, got, err, tc.want)
+		}
+	}
+	for _, spec := range []string{"", "3", "d", "0d6", "3d0", "3d6+", "1d6 drop lowest", "xd6", "3d6+2x"} {
+		if d, err := ParseDice(spec); err == nil {
+			t.Errorf("ParseDice(%q) = %v, <nil>, want error", spec, d)
+		}
+	}
+}
+
+func TestRoll(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		v, err := Roll("3d6+2")
+		if err != nil || v < 5 || v > 20 {
+			t.Fatalf(`Roll("3d6+2") = %d, %v`, v, err)
+		}
+		v, err = Roll("4d6 drop lowest")
+		if err != nil || v < 3 || v > 18 {
+			t.Fatalf(`Roll("4d6 drop lowest") = %d, %v`, v, err)
+		}
+	}
+	if _, err := Roll("nope"); err == nil {
+		t.Error(`Roll("nope") succeeded`)
+	}
+}
+
+func TestDiceString(t *testing.T) {
+	for _, spec := range []string{"3d6+2", "1d20", "2d8-1", "4d6 drop lowest"} {
+		d, err := ParseDice(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.String(); got != spec {
+			t.Errorf("ParseDice(%q).String() = %q", spec, got)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package rnd
+
+import "math"
+
+// Exponential returns an exponentially distributed float64 with the given
+// rate parameter (lambda), so its mean is 1/rate. It panics if rate <= 0.
+func Exponential(rate float64) float64 {
+	if !(rate > 0) {
+		panic("invalid rate for Exponential")
+	}
+	return ExpFloat64() / rate
+}
+
+// Distribution is a probability distribution over float64.
+type Distribution interface {
+	// Sample draws a value from the distribution, using g as the source of
+	// randomness.
+	Sample(g Generator) float64
+}
+
+// Density is a Distribution with a known probability density function.
+type Density interface {
+	Distribution
+	// PDF returns the probability density at x.
+	PDF(x float64) float64
+}
+
+// DistFunc adapts a function to the Distribution interface.
+type DistFunc func(g Generator) float64
+
+// Sample calls f(g).
+func (f DistFunc) Sample(g Generator) float64 {
+	return f(g)
+}
+
+// UniformDist is the uniform distribution on [Min, Max).
+type UniformDist struct {
+	Min, Max float64
+}
+
+// Sample draws a value from d.
+func (d UniformDist) Sample(g Generator) float64 {
+	return d.Min + g.Float64()*(d.Max-d.Min)
+}
+
+// PDF returns the probability density of d at x.
+func (d UniformDist) PDF(x float64) float64 {
+	if x < d.Min || x >= d.Max {
+		return 0
+	}
+	return 1 / (d.Max - d.Min)
+}
+
+// NormalDist is the normal distribution with mean Mu and standard deviation
+// Sigma.
+type NormalDist struct {
+	Mu, Sigma float64
+}
+
+// Sample draws a value from d.
+func (d NormalDist) Sample(g Generator) float64 {
+	return d.Mu + g.NormFloat64()*d.Sigma
+}
+
+// PDF returns the probability density of d at x.
+func (d NormalDist) PDF(x float64) float64 {
+	z := (x - d.Mu) / d.Sigma
+	return math.Exp(-z*z/2) / (d.Sigma * math.Sqrt(2*math.Pi))
+}
+
+// ExponentialDist is the exponential distribution with rate parameter Rate.
+type ExponentialDist struct {
+	Rate float64
+}
+
+// Sample draws a value from d.
+func (d ExponentialDist) Sample(g Generator) float64 {
+	return g.ExpFloat64() / d.Rate
+}
+
+// PDF returns the probability density of d at x.
+func (d ExponentialDist) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return d.Rate * math.Exp(-d.Rate*x)
+}
+
+var (
+	_ Density = UniformDist{}
+	_ Density = NormalDist{}
+	_ Density = ExponentialDist{}
+)
+
+// FillDist fills dst with values drawn from d. It synchronizes only once for
+// the whole fill, so it is faster than repeatedly sampling from Global.
+func FillDist(dst []float64, d Distribution) {
+	WithRand(func(r *Rand) {
+		for i := range dst {
+			dst[i] = d.Sample(r)
+		}
+	})
+}
+
+// FillDistMatrix fills all rows of m with values drawn from d, synchronizing
+// only once. The rows may have different lengths.
+func FillDistMatrix(m [][]float64, d Distribution) {
+	WithRand(func(r *Rand) {
+		for _, row := range m {
+			for i := range row {
+				row[i] = d.Sample(r)
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExponential(t *testing.T) {
+	const n = 10000
+	var sum float64
+	for i := 0; i < n; i++ {
+		v := Exponential(4)
+		if v <= 0 {
+			t.Fatalf("Exponential(4) = %v", v)
+		}
+		sum += v
+	}
+	// The standard deviation of the mean is 1/(4·√n) = 0.0025.
+	if mean := sum / n; math.Abs(mean-0.25) > 0.0125 {
+		t.Errorf("mean of Exponential(4) = %v, want 0.25", mean)
+	}
+}
+
+func TestFillDist(t *testing.T) {
+	dst := make([]float64, 1000)
+	FillDist(dst, UniformDist{Min: 2, Max: 3})
+	for i, v := range dst {
+		if v < 2 || v >= 3 {
+			t.Fatalf("dst[%d] = %v, want in [2,3)", i, v)
+		}
+	}
+
+	m := [][]float64{make([]float64, 3), make([]float64, 5)}
+	FillDistMatrix(m, DistFunc(func(g Generator) float64 { return -g.Float64() - 1 }))
+	for i, row := range m {
+		for j, v := range row {
+			if v > -1 || v <= -2 {
+				t.Fatalf("m[%d][%d] = %v, want in (-2,-1]", i, j, v)
+			}
+		}
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	const n = 10000
+	for _, tc := range []struct {
+		d    Distribution
+		mean float64
+	}{
+		{NormalDist{Mu: 5, Sigma: 2}, 5},
+		{ExponentialDist{Rate: 4}, 0.25},
+		{UniformDist{Min: -1, Max: 3}, 1},
+	} {
+		dst := make([]float64, n)
+		FillDist(dst, tc.d)
+		var sum float64
+		for _, v := range dst {
+			sum += v
+		}
+		if mean := sum / n; math.Abs(mean-tc.mean) > 0.1 {
+			t.Errorf("mean of %#v = %v, want %v", tc.d, mean, tc.mean)
+		}
+	}
+}
+
+func TestNormPair(t *testing.T) {
+	const n = 10000
+	var sx, sy, sxx, syy, sxy float64
+	r := New()
+	for i := 0; i < n; i++ {
+		x, y := NormPair()
+		if i%2 == 1 {
+			x, y = r.NormPair()
+		}
+		sx, sy = sx+x, sy+y
+		sxx, syy, sxy = sxx+x*x, syy+y*y, sxy+x*y
+	}
+	for name, v := range map[string]float64{
+		"mean of x":             sx / n,
+		"mean of y":             sy / n,
+		"variance of x":         sxx/n - 1,
+		"variance of y":         syy/n - 1,
+		"covariance of x and y": sxy / n,
+	} {
+		if math.Abs(v) > 0.05 {
+			t.Errorf("%s off by %v", name, v)
+		}
+	}
+}
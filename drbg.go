@@ -0,0 +1,85 @@
+package rnd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// drbgBlock is the number of bytes requested from the HMAC-DRBG in each
+// generate call.
+const drbgBlock = 256
+
+// NewDRBG returns a new Rand, using HMAC-DRBG with SHA-256, as specified in
+// NIST SP 800-90A, as its source. key is used as the entropy input and
+// personalization as the personalization string, with an empty nonce. The
+// stream is a pure function of key and personalization, so parties sharing a
+// key can derive identical sequences, while it is unpredictable to anyone not
+// knowing the key. For the full security strength of 256 bits, key should be
+// at least 32 random bytes.
+//
+// The source requests 256 bytes per generate call from the DRBG, without
+// additional input, and interprets them as a sequence of little-endian
+// uint64. Other implementations can reproduce the stream by doing the same.
+// The DRBG is never reseeded.
+func NewDRBG(key, personalization []byte) *Rand {
+	d := &drbg{
+		k: make([]byte, sha256.Size),
+		v: make([]byte, sha256.Size),
+	}
+	for i := range d.v {
+		d.v[i] = 1
+	}
+	seed := make([]byte, 0, len(key)+len(personalization))
+	seed = append(seed, key...)
+	seed = append(seed, personalization...)
+	d.update(seed)
+	d.pos = drbgBlock
+	return FromSource(d)
+}
+
+// drbg is a rand.Source implementing HMAC-DRBG with SHA-256.
+type drbg struct {
+	k, v []byte
+	buf  [drbgBlock]byte
+	pos  int
+}
+
+func (d *drbg) Uint64() uint64 {
+	if d.pos == drbgBlock {
+		d.generate(d.buf[:])
+		d.pos = 0
+	}
+	v := binary.LittleEndian.Uint64(d.buf[d.pos:])
+	d.pos += 8
+	return v
+}
+
+// update is the HMAC_DRBG_Update function.
+func (d *drbg) update(data []byte) {
+	for _, b := range []byte{0x00, 0x01} {
+		m := hmac.New(sha256.New, d.k)
+		m.Write(d.v)
+		m.Write([]byte{b})
+		m.Write(data)
+		d.k = m.Sum(d.k[:0])
+		m = hmac.New(sha256.New, d.k)
+		m.Write(d.v)
+		d.v = m.Sum(d.v[:0])
+		if len(data) == 0 {
+			return
+		}
+	}
+}
+
+// generate is the HMAC_DRBG_Generate function, without additional input.
+func (d *drbg) generate(p []byte) {
+	m := hmac.New(sha256.New, d.k)
+	for len(p) > 0 {
+		m.Reset()
+		m.Write(d.v)
+		d.v = m.Sum(d.v[:0])
+		p = p[copy(p, d.v):]
+	}
+	d.update(nil)
+}
@@ -0,0 +1,52 @@
+package rnd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestDRBG(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	a, b := NewDRBG(key, []byte("shard-1")), NewDRBG(key, []byte("shard-1"))
+	for range 100 {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("NewDRBG is not deterministic: %#x != %#x", x, y)
+		}
+	}
+	if NewDRBG(key, []byte("shard-2")).Uint64() == NewDRBG(key, []byte("shard-1")).Uint64() {
+		t.Error("different personalization strings give the same stream")
+	}
+	if NewDRBG([]byte("other"), []byte("shard-1")).Uint64() == NewDRBG(key, []byte("shard-1")).Uint64() {
+		t.Error("different keys give the same stream")
+	}
+}
+
+func TestDRBGVector(t *testing.T) {
+	// From the NIST CAVP test vectors for HMAC_DRBG (SHA-256, no reseed,
+	// no additional input, no personalization string), COUNT = 0. Entropy
+	// input and nonce are concatenated, as they are only used together.
+	// Two generate calls of 128 bytes are requested, the second is checked.
+	entropy := mustHex("ca851911349384bffe89de1cbdc46e6831e44d34a4fb935ee285dd14b71a7488" +
+		"659ba96c601dc69fc902940805ec0ca8")
+	want := mustHex("e528e9abf2dece54d47c7e75e5fe302149f817ea9fb4bee6f4199697d04d5b89" +
+		"d54fbb978a15b5c443c9ec21036d2460b6f73ebad0dc2aba6e624abf07745bc1" +
+		"07694bb7547bb0995f70de25d6b29e2d3011bb19d27676c07162c8b5ccde0668" +
+		"961df86803482cb37ed6d5c0bb8d50cf1f50d476aa0458bdaba806f48be9dcb8")
+	d := &drbg{k: make([]byte, 32), v: bytes.Repeat([]byte{1}, 32)}
+	d.update(entropy)
+	got := make([]byte, 128)
+	d.generate(got)
+	d.generate(got)
+	if !bytes.Equal(got, want) {
+		t.Errorf("HMAC-DRBG output = %x, want %x", got, want)
+	}
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,151 @@
+package rnd
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+)
+
+// DynamicWeighted chooses pseudo-random items with probability proportional
+// to their weights. Unlike a static table, weights can be updated in
+// O(log n) and items can be added, so it is suitable for adaptive choices,
+// like load-balancing. It is safe for concurrent use.
+type DynamicWeighted[T any] struct {
+	mu    sync.Mutex
+	items []T
+	w     []float64
+	// tree is a Fenwick tree over w, using 1-based indices.
+	tree []float64
+}
+
+// NewDynamicWeighted returns a DynamicWeighted choosing from items, with the
+// given weights. It panics if len(weights) != len(items) or any weight is
+// negative, NaN or infinite.
+func NewDynamicWeighted[T any](items []T, weights []float64) *DynamicWeighted[T] {
+	if len(weights) != len(items) {
+		panic("mismatched lengths in NewDynamicWeighted")
+	}
+	d := &DynamicWeighted[T]{
+		items: append([]T(nil), items...),
+		w:     make([]float64, len(weights)),
+		tree:  make([]float64, len(weights)+1),
+	}
+	for i, w := range weights {
+		checkDynamicWeight(w)
+		d.w[i] = w
+		d.tree[i+1] += w
+		// Build the tree in O(n), by propagating every node to its parent.
+		if p := i + 1 + (i+1)&-(i+1); p <= len(weights) {
+			d.tree[p] += d.tree[i+1]
+		}
+	}
+	return d
+}
+
+func checkDynamicWeight(w float64) {
+	if !(w >= 0) || math.IsInf(w, 1) {
+		panic("invalid weight in DynamicWeighted")
+	}
+}
+
+// Len returns the number of items.
+func (d *DynamicWeighted[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.items)
+}
+
+// Item returns the i'th item.
+func (d *DynamicWeighted[T]) Item(i int) T {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.items[i]
+}
+
+// Weight returns the weight of the i'th item.
+func (d *DynamicWeighted[T]) Weight(i int) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.w[i]
+}
+
+// Set sets the weight of the i'th item to w. It panics if w is negative, NaN
+// or infinite.
+func (d *DynamicWeighted[T]) Set(i int, w float64) {
+	checkDynamicWeight(w)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delta := w - d.w[i]
+	d.w[i] = w
+	for j := i + 1; j < len(d.tree); j += j & -j {
+		d.tree[j] += delta
+	}
+}
+
+// Add adds an item with weight w and returns its index. It panics if w is
+// negative, NaN or infinite.
+func (d *DynamicWeighted[T]) Add(item T, w float64) int {
+	checkDynamicWeight(w)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := len(d.items)
+	d.items = append(d.items, item)
+	d.w = append(d.w, w)
+	// The new node covers the range (i+1-lowbit, i+1], so sum up the nodes
+	// covering the prefix of that range, except the new item itself.
+	j := i + 1
+	sum := w
+	for k := j - 1; k > j-j&-j; k -= k & -k {
+		sum += d.tree[k]
+	}
+	d.tree = append(d.tree, sum)
+	return i
+}
+
+// Pick returns a pseudo-random item. It panics if the total weight is 0.
+func (d *DynamicWeighted[T]) Pick() T {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.items[d.pick()]
+}
+
+// PickIndex returns the index of a pseudo-random item. It panics if the
+// total weight is 0.
+func (d *DynamicWeighted[T]) PickIndex() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.pick()
+}
+
+func (d *DynamicWeighted[T]) pick() int {
+	n := len(d.w)
+	var total float64
+	for j := n; j > 0; j -= j & -j {
+		total += d.tree[j]
+	}
+	if !(total > 0) {
+		panic("zero total weight in DynamicWeighted")
+	}
+	u := Float64() * total
+	pos := 0
+	for step := 1 << (bits.Len(uint(n)) - 1); step > 0; step >>= 1 {
+		if pos+step <= n && d.tree[pos+step] <= u {
+			pos += step
+			u -= d.tree[pos]
+		}
+	}
+	// Rounding errors accumulated by updates can make the search land on an
+	// item with weight 0 or past the end. Choose the closest preceding item
+	// with positive weight, in that case.
+	for i := min(pos, n-1); i >= 0; i-- {
+		if d.w[i] > 0 {
+			return i
+		}
+	}
+	for i := pos + 1; i < n; i++ {
+		if d.w[i] > 0 {
+			return i
+		}
+	}
+	panic("zero total weight in DynamicWeighted")
+}
@@ -0,0 +1,59 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+// checkFenwick checks that the prefix sums of d.tree match d.w.
+func checkFenwick[T any](t *testing.T, d *DynamicWeighted[T]) {
+	t.Helper()
+	var want float64
+	for i, w := range d.w {
+		want += w
+		var got float64
+		for j := i + 1; j > 0; j -= j & -j {
+			got += d.tree[j]
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Fatalf("prefix sum %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestDynamicWeighted(t *testing.T) {
+	d := NewDynamicWeighted([]string{"a", "b", "c", "d", "e"}, []float64{1, 0, 2, 3, 4})
+	checkFenwick(t, d)
+	d.Set(1, 5)
+	d.Set(4, 0)
+	checkFenwick(t, d)
+	for i := 0; i < 6; i++ {
+		d.Add("x", float64(i))
+		checkFenwick(t, d)
+	}
+	if d.Len() != 11 {
+		t.Fatalf("Len() = %d, want 11", d.Len())
+	}
+
+	const n = 20000
+	counts := make([]int, d.Len())
+	for i := 0; i < n; i++ {
+		counts[d.PickIndex()]++
+	}
+	var total float64
+	for i := range counts {
+		total += d.Weight(i)
+	}
+	for i, c := range counts {
+		want := d.Weight(i) / total
+		if want == 0 && c != 0 {
+			t.Errorf("item %d with weight 0 picked %d times", i, c)
+		}
+		if f := float64(c) / n; math.Abs(f-want) > 0.02 {
+			t.Errorf("item %d picked with frequency %v, want %v", i, f, want)
+		}
+	}
+	if got := d.Pick(); got == "e" {
+		t.Errorf("Pick() = %q, which has weight 0", got)
+	}
+}
@@ -0,0 +1,266 @@
+package rnd
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// EmpiricalDist is the empirical distribution of a set of observations. It is
+// safe for concurrent use.
+type EmpiricalDist struct {
+	sorted []float64
+	interp bool
+}
+
+// Empirical returns the empirical distribution of samples, which draws every
+// observation with the same probability. It is meant to replay distributions
+// captured from real data, like request latencies or payload sizes. samples
+// is not modified or retained.
+//
+// Empirical panics if samples is empty or contains NaN.
+func Empirical(samples []float64) *EmpiricalDist {
+	if len(samples) == 0 {
+		panic("no samples passed to Empirical")
+	}
+	s := slices.Clone(samples)
+	for _, v := range s {
+		if math.IsNaN(v) {
+			panic("invalid sample in Empirical")
+		}
+	}
+	slices.Sort(s)
+	return &EmpiricalDist{sorted: s}
+}
+
+// Interpolated returns a continuous version of d. Instead of only returning
+// observed values, it interpolates linearly between adjacent observations, so
+// its CDF is the piecewise linear function through the sorted observations.
+// Its values are still in the range [min,max] of the observations.
+func (d *EmpiricalDist) Interpolated() *EmpiricalDist {
+	return &EmpiricalDist{sorted: d.sorted, interp: true}
+}
+
+// Sample draws a value from d.
+func (d *EmpiricalDist) Sample(g Generator) float64 {
+	n := len(d.sorted)
+	if !d.interp || n == 1 {
+		return d.sorted[g.Intn(n)]
+	}
+	u := g.Float64() * float64(n-1)
+	i := int(u)
+	lo, hi := d.sorted[i], d.sorted[i+1]
+	return lo + (u-float64(i))*(hi-lo)
+}
+
+// HistogramDist is a distribution given by a histogram. It is safe for
+// concurrent use.
+type HistogramDist struct {
+	edges []float64
+	// cum[i] is the total count of bins [0,i].
+	cum []uint64
+}
+
+// FromHistogram returns the distribution described by a histogram with the
+// given bins: a value is in [binEdges[i],binEdges[i+1]) with probability
+// proportional to counts[i] and uniformly distributed within its bin. Bins
+// with a count of 0 are never drawn. It is meant to turn histograms of
+// production metrics into load test inputs. binEdges and counts are not
+// retained.
+//
+// FromHistogram panics if len(binEdges) != len(counts)+1, if binEdges is not
+// strictly increasing or contains non-finite values, or if all counts are 0
+// or their sum overflows a uint64.
+func FromHistogram(binEdges []float64, counts []uint64) *HistogramDist {
+	if len(binEdges) != len(counts)+1 {
+		panic("mismatched lengths in FromHistogram")
+	}
+	for i, e := range binEdges {
+		if math.IsNaN(e) || math.IsInf(e, 0) || (i > 0 && !(binEdges[i-1] < e)) {
+			panic("invalid bin edges for FromHistogram")
+		}
+	}
+	d := &HistogramDist{
+		edges: slices.Clone(binEdges),
+		cum:   make([]uint64, len(counts)),
+	}
+	var total uint64
+	for i, c := range counts {
+		if total+c < total {
+			panic("invalid counts for FromHistogram")
+		}
+		total += c
+		d.cum[i] = total
+	}
+	if total == 0 {
+		panic("invalid counts for FromHistogram")
+	}
+	return d
+}
+
+// Sample draws a value from d.
+func (d *HistogramDist) Sample(g Generator) float64 {
+	v := g.Uint64n(d.cum[len(d.cum)-1])
+	i, _ := slices.BinarySearchFunc(d.cum, v, func(c, v uint64) int {
+		if c > v {
+			return 1
+		}
+		return -1
+	})
+	lo, hi := d.edges[i], d.edges[i+1]
+	x := lo + g.Float64()*(hi-lo)
+	// Rounding can give hi for narrow bins.
+	return min(x, math.Nextafter(hi, lo))
+}
+
+// PiecewiseLinearDist is a distribution with a piecewise linear density. It is
+// safe for concurrent use.
+type PiecewiseLinearDist struct {
+	xs, ds []float64
+	// cum[i] is the total mass of the segments [0,i].
+	cum []float64
+}
+
+// PiecewiseLinear returns the distribution on [xs[0],xs[len(xs)-1]], whose
+// density interpolates linearly between the points (xs[i], densities[i]). The
+// densities are normalized, so only their relative sizes matter. This makes
+// it easy to sketch the rough shape of a distribution. xs and densities are
+// not retained.
+//
+// PiecewiseLinear panics if len(xs) != len(densities), len(xs) < 2, xs is not
+// strictly increasing or contains non-finite values, a density is negative or
+// not finite, or all densities are 0.
+func PiecewiseLinear(xs, densities []float64) *PiecewiseLinearDist {
+	if len(xs) != len(densities) || len(xs) < 2 {
+		panic("invalid argument to PiecewiseLinear")
+	}
+	d := &PiecewiseLinearDist{
+		xs:  slices.Clone(xs),
+		ds:  slices.Clone(densities),
+		cum: make([]float64, len(xs)-1),
+	}
+	for i, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) || (i > 0 && !(xs[i-1] < x)) {
+			panic("invalid argument to PiecewiseLinear")
+		}
+		if !(densities[i] >= 0) || math.IsInf(densities[i], 0) {
+			panic("invalid density in PiecewiseLinear")
+		}
+	}
+	var total float64
+	for i := range d.cum {
+		total += (d.ds[i] + d.ds[i+1]) / 2 * (d.xs[i+1] - d.xs[i])
+		d.cum[i] = total
+	}
+	if !(total > 0) || math.IsInf(total, 0) {
+		panic("invalid density in PiecewiseLinear")
+	}
+	return d
+}
+
+// Sample draws a value from d.
+func (d *PiecewiseLinearDist) Sample(g Generator) float64 {
+	u := g.Float64() * d.cum[len(d.cum)-1]
+	i, _ := slices.BinarySearchFunc(d.cum, u, func(c, u float64) int {
+		if c > u {
+			return 1
+		}
+		return -1
+	})
+	if i == len(d.cum) {
+		// Only reached due to rounding errors.
+		i--
+	}
+	// Skip segments of zero mass, which can only be hit due to rounding.
+	for d.ds[i] == 0 && d.ds[i+1] == 0 {
+		i--
+	}
+	if i > 0 {
+		u -= d.cum[i-1]
+	}
+	if u <= 0 {
+		// The formula below is 0/0, if the density at the start of the
+		// segment is 0.
+		return d.xs[i]
+	}
+	// Invert the CDF of the trapezoid with densities a and b on [0,w],
+	// F(t) = a·t + (b-a)·t²/(2w). This form of the solution of the quadratic
+	// equation is stable and also works for a == b.
+	a, b, w := d.ds[i], d.ds[i+1], d.xs[i+1]-d.xs[i]
+	t := 2 * u / (a + math.Sqrt(max(a*a+2*(b-a)*u/w, 0)))
+	return min(d.xs[i]+t, d.xs[i+1])
+}
+
+// QuantileDist is a distribution given by its quantile function. It is safe
+// for concurrent use, if its quantile function is.
+type QuantileDist struct {
+	q func(p float64) float64
+	// table contains q((i+0.5)/n), for a distribution returned by
+	// Tabulated.
+	table []float64
+}
+
+// FromQuantile returns the distribution with the quantile function (inverse
+// CDF) q, which must be non-decreasing on (0,1). It draws values by inverse
+// transform sampling, applying q to uniform numbers in the open interval
+// (0,1), so q does not need to handle 0 or 1. This makes it possible to
+// sample from any distribution with a known quantile function, like those of
+// gonum.org/v1/gonum/stat/distuv.
+func FromQuantile(q func(p float64) float64) *QuantileDist {
+	return &QuantileDist{q: q}
+}
+
+// Check evaluates the quantile function at n evenly spaced points and returns
+// an error, if it returns NaN or is decreasing at any of them. It panics if
+// n < 2.
+func (d *QuantileDist) Check(n int) error {
+	if n < 2 {
+		panic("invalid argument to QuantileDist.Check")
+	}
+	prev := math.Inf(-1)
+	for i := range n {
+		p := (float64(i) + 0.5) / float64(n)
+		v := d.q(p)
+		if math.IsNaN(v) {
+			return fmt.Errorf("rnd: quantile function returns NaN at %v", p)
+		}
+		if v < prev {
+			return fmt.Errorf("rnd: quantile function is decreasing at %v", p)
+		}
+		prev = v
+	}
+	return nil
+}
+
+// Tabulated returns a distribution approximating d, which evaluates the
+// quantile function of d at n evenly spaced points once and interpolates
+// linearly between them. In the tails, below the first and above the last
+// point, it still calls the quantile function of d. This makes sampling
+// cheaper, if the quantile function is expensive. It panics if n < 2.
+func (d *QuantileDist) Tabulated(n int) *QuantileDist {
+	if n < 2 {
+		panic("invalid argument to QuantileDist.Tabulated")
+	}
+	table := make([]float64, n)
+	for i := range table {
+		table[i] = d.q((float64(i) + 0.5) / float64(n))
+	}
+	return &QuantileDist{q: d.q, table: table}
+}
+
+// Sample draws a value from d.
+func (d *QuantileDist) Sample(g Generator) float64 {
+	// A uniform number in (0,1), like Float64OO.
+	p := (float64(g.Uint64()>>12) + 0.5) / (1 << 52)
+	if d.table == nil {
+		return d.q(p)
+	}
+	n := len(d.table)
+	x := p*float64(n) - 0.5
+	if x < 0 || x >= float64(n-1) {
+		return d.q(p)
+	}
+	i := int(x)
+	f := x - float64(i)
+	return d.table[i] + f*(d.table[i+1]-d.table[i])
+}
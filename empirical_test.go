@@ -0,0 +1,165 @@
+package rnd
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestEmpirical(t *testing.T) {
+	samples := []float64{3, 1, 2, 2}
+	d := Empirical(samples)
+	if !slices.Equal(samples, []float64{3, 1, 2, 2}) {
+		t.Fatalf("Empirical modified samples: %v", samples)
+	}
+	const n = 10000
+	counts := make(map[float64]int)
+	dst := make([]float64, n)
+	FillDist(dst, d)
+	for _, v := range dst {
+		counts[v]++
+	}
+	if len(counts) != 3 || math.Abs(float64(counts[2])-n/2) > 300 || math.Abs(float64(counts[1])-n/4) > 300 {
+		t.Errorf("Empirical(%v) drew values %v", samples, counts)
+	}
+
+	FillDist(dst, d.Interpolated())
+	var sum float64
+	for _, v := range dst {
+		if v < 1 || v > 3 {
+			t.Fatalf("Interpolated drew %v, want in [1,3]", v)
+		}
+		sum += v
+	}
+	// The interpolated CDF is uniform on [1,2] and [2,3], with the same mass.
+	if mean := sum / n; math.Abs(mean-2) > 0.05 {
+		t.Errorf("mean of Interpolated = %v, want 2", mean)
+	}
+
+	if v := Empirical([]float64{42}).Interpolated().Sample(Global()); v != 42 {
+		t.Errorf("Empirical([42]).Interpolated() drew %v", v)
+	}
+}
+
+func TestFromHistogram(t *testing.T) {
+	d := FromHistogram([]float64{0, 1, 2, 10}, []uint64{1, 0, 3})
+	const n = 10000
+	dst := make([]float64, n)
+	FillDist(dst, d)
+	var first int
+	for _, v := range dst {
+		switch {
+		case v >= 0 && v < 1:
+			first++
+		case v >= 2 && v < 10:
+		default:
+			t.Fatalf("FromHistogram drew %v", v)
+		}
+	}
+	if math.Abs(float64(first)-n/4) > 300 {
+		t.Errorf("FromHistogram drew %d of %d values from the first bin, want %d", first, n, n/4)
+	}
+
+	for name, f := range map[string]func(){
+		"mismatched": func() { FromHistogram([]float64{0, 1}, []uint64{1, 1}) },
+		"decreasing": func() { FromHistogram([]float64{1, 0}, []uint64{1}) },
+		"empty":      func() { FromHistogram([]float64{0, 1}, []uint64{0}) },
+		"overflow":   func() { FromHistogram([]float64{0, 1, 2}, []uint64{math.MaxUint64, 1}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FromHistogram with %s arguments did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestPiecewiseLinear(t *testing.T) {
+	const n = 20000
+	for _, tc := range []struct {
+		xs, ds []float64
+		mean   float64
+	}{
+		// Uniform on [2,4].
+		{[]float64{2, 4}, []float64{1, 1}, 3},
+		// Triangular on [0,1] with mode 1.
+		{[]float64{0, 1}, []float64{0, 5}, 2.0 / 3},
+		// Symmetric triangle on [0,2], with a gap in density.
+		{[]float64{-1, 0, 1, 2}, []float64{0, 0, 1, 0}, 1},
+	} {
+		dst := make([]float64, n)
+		FillDist(dst, PiecewiseLinear(tc.xs, tc.ds))
+		lo, hi := tc.xs[0], tc.xs[len(tc.xs)-1]
+		for _, v := range dst {
+			if v < lo || v > hi {
+				t.Fatalf("PiecewiseLinear(%v, %v) drew %v", tc.xs, tc.ds, v)
+			}
+		}
+		if m := mean(dst); math.Abs(m-tc.mean) > 0.02 {
+			t.Errorf("mean of PiecewiseLinear(%v, %v) = %v, want %v", tc.xs, tc.ds, m, tc.mean)
+		}
+	}
+	// Uniform values landing exactly on the start of a segment with density
+	// 0 at its start. Float64 uses the low 53 bits of the source, so
+	// constSource(1<<52) yields 0.5.
+	for _, tc := range []struct {
+		xs, ds []float64
+		src    constSource
+		want   float64
+	}{
+		{[]float64{0, 1}, []float64{0, 1}, 0, 0},
+		{[]float64{0, 1, 2}, []float64{1, 0, 1}, 1 << 52, 1},
+		{[]float64{0, 1, 2}, []float64{0, 1, 0}, 0, 0},
+	} {
+		g := FromSource(tc.src)
+		if v := PiecewiseLinear(tc.xs, tc.ds).Sample(g); v != tc.want {
+			t.Errorf("PiecewiseLinear(%v, %v).Sample(%d) = %v, want %v", tc.xs, tc.ds, tc.src, v, tc.want)
+		}
+	}
+	for name, f := range map[string]func(){
+		"short":    func() { PiecewiseLinear([]float64{0}, []float64{1}) },
+		"negative": func() { PiecewiseLinear([]float64{0, 1}, []float64{1, -1}) },
+		"zero":     func() { PiecewiseLinear([]float64{0, 1}, []float64{0, 0}) },
+		"unsorted": func() { PiecewiseLinear([]float64{1, 0}, []float64{1, 1}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("PiecewiseLinear with %s arguments did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestFromQuantile(t *testing.T) {
+	// The quantile function of the exponential distribution with rate 2.
+	exp := func(p float64) float64 { return -math.Log1p(-p) / 2 }
+	d := FromQuantile(exp)
+	if err := d.Check(1000); err != nil {
+		t.Fatalf("Check() = %v", err)
+	}
+	const n = 20000
+	dst := make([]float64, n)
+	for _, d := range []*QuantileDist{d, d.Tabulated(100)} {
+		FillDist(dst, d)
+		for _, v := range dst {
+			if !(v > 0) || math.IsInf(v, 0) {
+				t.Fatalf("FromQuantile drew %v", v)
+			}
+		}
+		if m := mean(dst); math.Abs(m-0.5) > 0.02 {
+			t.Errorf("mean = %v, want 0.5", m)
+		}
+	}
+	if err := FromQuantile(func(p float64) float64 { return -p }).Check(10); err == nil {
+		t.Error("Check() = <nil> for decreasing quantile function")
+	}
+	if err := FromQuantile(func(p float64) float64 { return math.NaN() }).Check(10); err == nil {
+		t.Error("Check() = <nil> for NaN quantile function")
+	}
+}
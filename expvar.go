@@ -0,0 +1,17 @@
+package rnd
+
+import (
+	"expvar"
+	"sync"
+)
+
+var publishOnce sync.Once
+
+// PublishExpvar publishes the Stats of the global source as the expvar
+// variable "rnd". It can be called multiple times, but only publishes the
+// variable once.
+func PublishExpvar() {
+	publishOnce.Do(func() {
+		expvar.Publish("rnd", expvar.Func(func() any { return Stats() }))
+	})
+}
@@ -0,0 +1,24 @@
+package rnd
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	Uint64()
+	PublishExpvar()
+	PublishExpvar()
+	v := expvar.Get("rnd")
+	if v == nil {
+		t.Fatal("expvar rnd not published")
+	}
+	var st Statistics
+	if err := json.Unmarshal([]byte(v.String()), &st); err != nil {
+		t.Fatalf("expvar rnd = %s: %v", v.String(), err)
+	}
+	if st.Generated == 0 {
+		t.Errorf("Generated = 0, want > 0")
+	}
+}
@@ -0,0 +1,95 @@
+// Package fake generates fake, but realistic looking, data for test
+// fixtures, like names and addresses.
+//
+// All values are drawn from the global source of package gonih.org/rnd, so
+// the functions in this package are safe for concurrent use. The embedded
+// lists are small and the output is not meant to be realistic for any
+// particular country.
+package fake
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gonih.org/rnd"
+)
+
+//go:embed words
+var wordsFS embed.FS
+
+// wordList returns a function returning the words from the given file in the
+// words directory, one per line.
+func wordList(name string) func() []string {
+	return sync.OnceValue(func() []string {
+		b, err := wordsFS.ReadFile("words/" + name)
+		if err != nil {
+			panic(err)
+		}
+		return strings.Fields(string(b))
+	})
+}
+
+var (
+	firstNames      = wordList("first.txt")
+	lastNames       = wordList("last.txt")
+	streets         = wordList("streets.txt")
+	streetSuffixes  = wordList("street_suffixes.txt")
+	companySuffixes = wordList("company_suffixes.txt")
+	cities          = wordList("cities.txt")
+)
+
+// pick returns a random element of s.
+func pick(s []string) string {
+	return s[rnd.Intn(len(s))]
+}
+
+// FirstName returns a random given name, like "Amara".
+func FirstName() string {
+	return pick(firstNames())
+}
+
+// LastName returns a random family name, like "Okafor".
+func LastName() string {
+	return pick(lastNames())
+}
+
+// Name returns a random full name, like "Amara Okafor".
+func Name() string {
+	return FirstName() + " " + LastName()
+}
+
+// emailDomains are reserved for documentation by RFC 2606, so fake addresses
+// never reach a real person.
+var emailDomains = []string{"example.com", "example.net", "example.org"}
+
+// Email returns a random email address, like "amara.okafor42@example.com".
+// The domain is always one reserved for documentation.
+func Email() string {
+	return fmt.Sprintf("%s.%s%d@%s", strings.ToLower(FirstName()), strings.ToLower(LastName()), rnd.Intn(100), pick(emailDomains))
+}
+
+// StreetAddress returns a random street address, like "742 Maple Avenue".
+func StreetAddress() string {
+	return fmt.Sprintf("%d %s %s", 1+rnd.Intn(9999), pick(streets()), pick(streetSuffixes()))
+}
+
+// City returns a random, made up city name, like "Riverside".
+func City() string {
+	return pick(cities())
+}
+
+// Address returns a random full address, like
+// "742 Maple Avenue, Riverside 12345".
+func Address() string {
+	return fmt.Sprintf("%s, %s %05d", StreetAddress(), City(), rnd.Intn(100000))
+}
+
+// Company returns a random company name, like "Okafor & Rossi LLC".
+func Company() string {
+	if rnd.Intn(3) == 0 {
+		return LastName() + " & " + LastName() + " " + pick(companySuffixes())
+	}
+	return LastName() + " " + pick(companySuffixes())
+}
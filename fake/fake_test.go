@@ -0,0 +1,31 @@
+package fake
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestFake(t *testing.T) {
+	tcs := []struct {
+		name string
+		f    func() string
+		re   string
+	}{
+		{"FirstName", FirstName, `^[A-Z][a-z]+$`},
+		{"LastName", LastName, `^[A-Z][a-z]+$`},
+		{"Name", Name, `^[A-Z][a-z]+ [A-Z][a-z]+$`},
+		{"Email", Email, `^[a-z]+\.[a-z]+[0-9]{1,2}@example\.(com|net|org)$`},
+		{"StreetAddress", StreetAddress, `^[1-9][0-9]* [A-Z][a-z]+ [A-Z][a-z]+$`},
+		{"City", City, `^[A-Z][a-z]+$`},
+		{"Address", Address, `^[1-9][0-9]* [A-Z][a-z]+ [A-Z][a-z]+, [A-Z][a-z]+ [0-9]{5}$`},
+		{"Company", Company, `^[A-Z][a-z]+( & [A-Z][a-z]+)? [A-Z][A-Za-z.]+$`},
+	}
+	for _, tc := range tcs {
+		re := regexp.MustCompile(tc.re)
+		for i := 0; i < 100; i++ {
+			if s := tc.f(); !re.MatchString(s) {
+				t.Fatalf("%s() = %q, does not match %s", tc.name, s, tc.re)
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package rnd
+
+// chance reports true with probability p. It panics with msg, if p is not in
+// [0,1].
+func chance(p float64, msg string) bool {
+	if !(p >= 0 && p <= 1) {
+		panic(msg)
+	}
+	return Float64() < p
+}
+
+// Fail returns err with probability p and nil otherwise. It is meant for
+// injecting faults in chaos tests, for example:
+//
+//	if err := rnd.Fail(0.01, io.ErrUnexpectedEOF); err != nil {
+//		return err
+//	}
+//
+// Fail panics if p is not in [0,1].
+func Fail(p float64, err error) error {
+	if chance(p, "invalid probability for Fail") {
+		return err
+	}
+	return nil
+}
+
+// Maybe calls fn with probability p. It panics if p is not in [0,1].
+func Maybe(p float64, fn func()) {
+	if chance(p, "invalid probability for Maybe") {
+		fn()
+	}
+}
@@ -0,0 +1,53 @@
+package rnd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFail(t *testing.T) {
+	const n = 10000
+	errTest := errors.New("test")
+	var fails int
+	for range n {
+		switch err := Fail(0.3, errTest); err {
+		case nil:
+		case errTest:
+			fails++
+		default:
+			t.Fatalf("Fail(0.3, errTest) = %v", err)
+		}
+	}
+	if got := float64(fails) / n; got < 0.27 || got > 0.33 {
+		t.Errorf("Fail(0.3, errTest) failed with probability %v, want 0.3", got)
+	}
+	if Fail(0, errTest) != nil {
+		t.Errorf("Fail(0, errTest) != nil")
+	}
+	if Fail(1, errTest) != errTest {
+		t.Errorf("Fail(1, errTest) != errTest")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Fail(2, errTest) did not panic")
+		}
+	}()
+	Fail(2, errTest)
+}
+
+func TestMaybe(t *testing.T) {
+	var calls int
+	for range 10000 {
+		Maybe(0.7, func() { calls++ })
+	}
+	if calls < 6700 || calls > 7300 {
+		t.Errorf("Maybe(0.7, fn) called fn %d times out of 10000, want about 7000", calls)
+	}
+	Maybe(0, func() { t.Errorf("Maybe(0, fn) called fn") })
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Maybe(-1, fn) did not panic")
+		}
+	}()
+	Maybe(-1, func() {})
+}
@@ -0,0 +1,305 @@
+// Package fill populates values with random data, for test fixtures.
+//
+// The generated values can be controlled using struct tags with the key
+// "rnd". The tag value is a comma-separated list of options:
+//
+//	min=N        minimum value of a number (inclusive)
+//	max=N        maximum value of a number (inclusive for integers,
+//	             exclusive for floats)
+//	len=N        length of a string, slice or map
+//	minlen=N     minimum length of a string, slice or map
+//	maxlen=N     maximum length of a string, slice or map
+//	oneof=a|b|c  a value chosen from the given list
+//	-            leave the field alone
+//
+// Options apply to the field itself or, for slices, arrays, maps and
+// pointers, to their elements. Strings consist of ASCII letters and digits.
+// Without options, integers span their whole range, floats are in [0,1) and
+// strings, slices and maps have a length in [0,8]. time.Time values are
+// between 1970 and 2100.
+//
+// Map keys are filled without options and duplicates are redrawn. If the key
+// type has too few values for the minimum length of a map, like bool, Fill
+// returns an error. Without a minimum length, such maps are shorter.
+//
+// Unexported fields, interfaces, channels and functions are left alone.
+// Pointers are set to newly allocated values, up to a maximum depth, to
+// support recursive types.
+package fill
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gonih.org/rnd"
+)
+
+// maxDepth is the maximum nesting depth of pointers, slices and maps. Deeper
+// values are left at their zero value.
+const maxDepth = 8
+
+// maxCollisions is the number of duplicate keys drawn in a row, after which
+// filling a map gives up.
+const maxCollisions = 100
+
+const alphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	minTime  = time.Unix(0, 0)
+	maxTime  = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Fill populates the value pointed to by v with random values. It returns an
+// error, if v is not a non-nil pointer or a struct tag is invalid.
+func Fill(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("fill: argument must be a non-nil pointer")
+	}
+	if err := fill(rv.Elem(), opts{}, 0); err != nil {
+		return fmt.Errorf("fill: %w", err)
+	}
+	return nil
+}
+
+// opts are the parsed options of a struct tag.
+type opts struct {
+	min, max       *string
+	minLen, maxLen int
+	hasLen         bool
+	oneof          []string
+}
+
+func parseTag(tag string) (o opts, skip bool, err error) {
+	if tag == "-" {
+		return o, true, nil
+	}
+	if tag == "" {
+		return o, false, nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		k, v, _ := strings.Cut(opt, "=")
+		switch k {
+		case "min":
+			o.min = &v
+		case "max":
+			o.max = &v
+		case "len", "minlen", "maxlen":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return o, false, fmt.Errorf("invalid %s %q", k, v)
+			}
+			if !o.hasLen {
+				o.minLen, o.maxLen, o.hasLen = 0, n, true
+			}
+			switch k {
+			case "len":
+				o.minLen, o.maxLen = n, n
+			case "minlen":
+				o.minLen = n
+			case "maxlen":
+				o.maxLen = n
+			}
+		case "oneof":
+			o.oneof = strings.Split(v, "|")
+		default:
+			return o, false, fmt.Errorf("unknown option %q", k)
+		}
+	}
+	if o.hasLen && o.minLen > o.maxLen {
+		return o, false, fmt.Errorf("minlen %d > maxlen %d", o.minLen, o.maxLen)
+	}
+	return o, false, nil
+}
+
+// length returns a random length according to o.
+func (o opts) length() int {
+	lo, hi := 0, 8
+	if o.hasLen {
+		lo, hi = o.minLen, o.maxLen
+	}
+	return lo + rnd.Intn(hi-lo+1)
+}
+
+func fill(v reflect.Value, o opts, depth int) error {
+	if len(o.oneof) > 0 && v.Kind() != reflect.Slice && v.Kind() != reflect.Array && v.Kind() != reflect.Map && v.Kind() != reflect.Pointer {
+		return setString(v, o.oneof[rnd.Intn(len(o.oneof))])
+	}
+	if v.Type() == timeType {
+		v.Set(reflect.ValueOf(rnd.TimeBetween(minTime, maxTime)))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(rnd.Uint32()&1 != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := v.Type().Bits()
+		lo, hi := int64(-1)<<(bits-1), int64(uint64(1)<<(bits-1)-1)
+		if err := parseBounds(o, &lo, &hi, func(s string) (int64, error) { return strconv.ParseInt(s, 0, bits) }); err != nil {
+			return err
+		}
+		if lo > hi {
+			return fmt.Errorf("min %d > max %d", lo, hi)
+		}
+		span := uint64(hi-lo) + 1
+		if span == 0 {
+			v.SetInt(int64(rnd.Uint64()))
+		} else {
+			v.SetInt(lo + int64(rnd.Uint64n(span)))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lo, hi := uint64(0), uint64(math.MaxUint64)>>(64-v.Type().Bits())
+		if err := parseBounds(o, &lo, &hi, func(s string) (uint64, error) { return strconv.ParseUint(s, 0, v.Type().Bits()) }); err != nil {
+			return err
+		}
+		if lo > hi {
+			return fmt.Errorf("min %d > max %d", lo, hi)
+		}
+		if span := hi - lo + 1; span == 0 {
+			v.SetUint(rnd.Uint64())
+		} else {
+			v.SetUint(lo + rnd.Uint64n(span))
+		}
+	case reflect.Float32, reflect.Float64:
+		lo, hi := 0.0, 1.0
+		if err := parseBounds(o, &lo, &hi, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }); err != nil {
+			return err
+		}
+		if !(lo < hi) {
+			return fmt.Errorf("min %v >= max %v", lo, hi)
+		}
+		v.SetFloat(lo + (hi-lo)*rnd.Float64())
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(rnd.Float64(), rnd.Float64()))
+	case reflect.String:
+		v.SetString(rnd.ID(o.length(), alphanumeric))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := fill(v.Index(i), o, depth); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if depth >= maxDepth {
+			return nil
+		}
+		n := o.length()
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if err := fill(s.Index(i), opts{oneof: o.oneof, min: o.min, max: o.max}, depth+1); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Map:
+		if depth >= maxDepth {
+			return nil
+		}
+		n := o.length()
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		// Redraw duplicate keys, until the key type seems to be exhausted.
+		for collisions := 0; m.Len() < n && collisions < maxCollisions; {
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := fill(k, opts{}, depth+1); err != nil {
+				return err
+			}
+			if m.MapIndex(k).IsValid() {
+				collisions++
+				continue
+			}
+			collisions = 0
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := fill(e, opts{oneof: o.oneof, min: o.min, max: o.max}, depth+1); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, e)
+		}
+		if m.Len() < o.minLen {
+			return fmt.Errorf("can not generate %d distinct keys of type %v", o.minLen, v.Type().Key())
+		}
+		v.Set(m)
+	case reflect.Pointer:
+		if depth >= maxDepth {
+			return nil
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := fill(p.Elem(), o, depth+1); err != nil {
+			return err
+		}
+		v.Set(p)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fo, skip, err := parseTag(f.Tag.Get("rnd"))
+			if err != nil {
+				return fmt.Errorf("field %s.%s: %w", t, f.Name, err)
+			}
+			if skip {
+				continue
+			}
+			if err := fill(v.Field(i), fo, depth); err != nil {
+				return fmt.Errorf("field %s.%s: %w", t, f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func parseBounds[T any](o opts, lo, hi *T, parse func(string) (T, error)) error {
+	if o.min != nil {
+		v, err := parse(*o.min)
+		if err != nil {
+			return fmt.Errorf("invalid min: %w", err)
+		}
+		*lo = v
+	}
+	if o.max != nil {
+		v, err := parse(*o.max)
+		if err != nil {
+			return fmt.Errorf("invalid max: %w", err)
+		}
+		*hi = v
+	}
+	return nil
+}
+
+// setString parses s into v, for the oneof option.
+func setString(v reflect.Value, s string) error {
+	var err error
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(s, 0, v.Type().Bits())
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		u, err = strconv.ParseUint(s, 0, v.Type().Bits())
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, v.Type().Bits())
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("oneof is not supported for %v", v.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("invalid oneof value: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package fill
+
+import (
+	"testing"
+	"time"
+)
+
+type Address struct {
+	Street string `rnd:"minlen=5,maxlen=20"`
+	Zip    string `rnd:"oneof=10115|20095|80331"`
+}
+
+type Person struct {
+	Name     string          `rnd:"len=8"`
+	Age      int             `rnd:"min=18,max=99"`
+	Score    float64         `rnd:"min=-1,max=1"`
+	Level    uint8           `rnd:"oneof=1|2|3"`
+	Tags     []string        `rnd:"len=3"`
+	Ratings  []int           `rnd:"len=4,min=1,max=5"`
+	Attrs    map[string]bool `rnd:"maxlen=3"`
+	Home     Address
+	Work     *Address
+	Friends  []*Person `rnd:"maxlen=2"`
+	Born     time.Time
+	Ignored  int `rnd:"-"`
+	internal int
+}
+
+func TestFill(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		p := Person{Ignored: 42}
+		if err := Fill(&p); err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Name) != 8 {
+			t.Errorf("Name = %q, want length 8", p.Name)
+		}
+		if p.Age < 18 || p.Age > 99 {
+			t.Errorf("Age = %d, want in [18,99]", p.Age)
+		}
+		if p.Score < -1 || p.Score >= 1 {
+			t.Errorf("Score = %v, want in [-1,1)", p.Score)
+		}
+		if p.Level < 1 || p.Level > 3 {
+			t.Errorf("Level = %d, want one of 1, 2, 3", p.Level)
+		}
+		if len(p.Tags) != 3 {
+			t.Errorf("Tags = %q, want length 3", p.Tags)
+		}
+		for _, r := range p.Ratings {
+			if r < 1 || r > 5 {
+				t.Errorf("Ratings = %v, want elements in [1,5]", p.Ratings)
+			}
+		}
+		if len(p.Attrs) > 3 {
+			t.Errorf("Attrs = %v, want at most 3 elements", p.Attrs)
+		}
+		if l := len(p.Home.Street); l < 5 || l > 20 {
+			t.Errorf("Home.Street = %q, want length in [5,20]", p.Home.Street)
+		}
+		if z := p.Home.Zip; z != "10115" && z != "20095" && z != "80331" {
+			t.Errorf("Home.Zip = %q", z)
+		}
+		if p.Work == nil {
+			t.Errorf("Work = nil")
+		}
+		if p.Born.Before(minTime) || !p.Born.Before(maxTime) {
+			t.Errorf("Born = %v", p.Born)
+		}
+		if p.Ignored != 42 || p.internal != 0 {
+			t.Errorf("Ignored = %d, internal = %d, want 42, 0", p.Ignored, p.internal)
+		}
+	}
+}
+
+func TestFillErrors(t *testing.T) {
+	var x int
+	tcs := []any{
+		nil,
+		x,
+		(*int)(nil),
+		&struct {
+			X int `rnd:"min=5,max=1"`
+		}{},
+		&struct {
+			X int `rnd:"min=foo"`
+		}{},
+		&struct {
+			X string `rnd:"bogus"`
+		}{},
+		&struct {
+			X int `rnd:"oneof=a|b"`
+		}{},
+		&struct {
+			X int8 `rnd:"max=1000"`
+		}{},
+		&struct {
+			X map[bool]int `rnd:"len=5"`
+		}{},
+	}
+	for _, v := range tcs {
+		if err := Fill(v); err == nil {
+			t.Errorf("Fill(%#v) succeeded", v)
+		}
+	}
+}
+
+func TestFillMap(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		var v struct {
+			S map[string]int `rnd:"len=5"`
+			B map[bool]int   `rnd:"minlen=1,maxlen=5"`
+			U map[uint8]int  `rnd:"len=200"`
+		}
+		if err := Fill(&v); err != nil {
+			t.Fatal(err)
+		}
+		if len(v.S) != 5 {
+			t.Fatalf("len(S) = %d, want 5", len(v.S))
+		}
+		if len(v.B) < 1 || len(v.B) > 2 {
+			t.Fatalf("len(B) = %d, want in [1,2]", len(v.B))
+		}
+		if len(v.U) != 200 {
+			t.Fatalf("len(U) = %d, want 200", len(v.U))
+		}
+	}
+}
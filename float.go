@@ -0,0 +1,81 @@
+package rnd
+
+import (
+	"math"
+	"math/bits"
+	"unsafe"
+)
+
+// Float64OO returns, as a float64, a pseudo-random number in the open
+// interval (0.0,1.0).
+func Float64OO() float64 {
+	// k+0.5 needs at most 53 bits of mantissa, so the result is exact.
+	return (float64(Uint64()>>12) + 0.5) / (1 << 52)
+}
+
+// Float64OC returns, as a float64, a pseudo-random number in the half-open
+// interval (0.0,1.0].
+func Float64OC() float64 {
+	return float64(Uint64()>>11+1) / (1 << 53)
+}
+
+// Float64CC returns, as a float64, a pseudo-random number in the closed
+// interval [0.0,1.0].
+func Float64CC() float64 {
+	return float64(Uint64n(1<<53+1)) / (1 << 53)
+}
+
+// Float64Exact returns, as a float64, a pseudo-random number in [0.0,1.0).
+// Unlike Float64, which returns multiples of 2^-53, every float64 in [0,1),
+// including subnormals, can be returned, with probability proportional to
+// the distance to the next larger float64. That is, the result is a uniform
+// real number in [0,1), rounded down.
+//
+// Float64Exact is meant for testing numerical code near 0. It usually draws
+// two 64-bit values.
+func Float64Exact() float64 {
+	// The exponent is geometrically distributed: the value is in
+	// [2^-(z+1),2^-z) with probability 2^-(z+1).
+	z := 0
+	for {
+		x := Uint64()
+		z += bits.LeadingZeros64(x)
+		if x != 0 || z >= 1022 {
+			break
+		}
+	}
+	mant := Uint64() >> 12
+	if z >= 1022 {
+		// Subnormals are evenly spaced in [0,2^-1022).
+		return math.Float64frombits(mant)
+	}
+	return math.Float64frombits(uint64(1022-z)<<52 | mant)
+}
+
+// Float is a constraint for floating point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Uniform returns a pseudo-random number in the half-open interval [lo,hi).
+// For 32-bit types, it is computed in 32-bit precision, without converting
+// from float64. It panics if lo >= hi or either bound is not finite.
+func Uniform[T Float](lo, hi T) T {
+	if !(lo < hi) || math.IsInf(float64(lo), 0) || math.IsInf(float64(hi), 0) {
+		panic("invalid argument to Uniform")
+	}
+	for {
+		var u T
+		if unsafe.Sizeof(u) == 4 {
+			u = T(Float32())
+		} else {
+			u = T(Float64())
+		}
+		// Interpolating avoids overflow of hi-lo for large intervals.
+		v := lo*(1-u) + hi*u
+		// Rounding can take v out of [lo,hi). That is rare, so retry.
+		if v >= lo && v < hi {
+			return v
+		}
+	}
+}
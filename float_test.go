@@ -0,0 +1,100 @@
+package rnd
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestFloatIntervals(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		if v := Float64OO(); v <= 0 || v >= 1 {
+			t.Fatalf("Float64OO() = %v", v)
+		}
+		if v := Float64OC(); v <= 0 || v > 1 {
+			t.Fatalf("Float64OC() = %v", v)
+		}
+		if v := Float64CC(); v < 0 || v > 1 {
+			t.Fatalf("Float64CC() = %v", v)
+		}
+	}
+}
+
+// seqSource is a rand.Source returning the values of a slice, repeating the
+// last one.
+type seqSource struct {
+	mu sync.Mutex
+	vs []uint64
+}
+
+func (s *seqSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.vs[0]
+	if len(s.vs) > 1 {
+		s.vs = s.vs[1:]
+	}
+	return v
+}
+
+func TestFloat64Exact(t *testing.T) {
+	var sum float64
+	const n = 10000
+	for i := 0; i < n; i++ {
+		v := Float64Exact()
+		if v < 0 || v >= 1 {
+			t.Fatalf("Float64Exact() = %v", v)
+		}
+		sum += v
+	}
+	if mean := sum / n; math.Abs(mean-0.5) > 0.02 {
+		t.Errorf("mean of Float64Exact = %v, want 0.5", mean)
+	}
+
+	defer SetSource(nil)
+	tcs := []struct {
+		vs   []uint64
+		want float64
+	}{
+		{[]uint64{1 << 63, 0}, 0.5},
+		{[]uint64{1 << 63, math.MaxUint64}, math.Nextafter(1, 0)},
+		{[]uint64{0, 1 << 63, 0}, 0x1p-65},
+		{[]uint64{0, 0, 1, 1 << 12}, 0x1p-192 * (1 + 0x1p-52)},
+		{append(make([]uint64, 16), 1<<12), 0x1p-1074},
+		{[]uint64{0, 0}, 0},
+	}
+	for _, tc := range tcs {
+		SetSource(&seqSource{vs: tc.vs})
+		if got := Float64Exact(); got != tc.want {
+			t.Errorf("Float64Exact() with source %#x = %v, want %v", tc.vs, got, tc.want)
+		}
+	}
+}
+
+func TestUniform(t *testing.T) {
+	type myFloat float32
+	for range 1000 {
+		if v := Uniform(-2.5, 3.0); v < -2.5 || v >= 3 {
+			t.Fatalf("Uniform(-2.5, 3) = %v", v)
+		}
+		if v := Uniform[float32](1, 1.5); v < 1 || v >= 1.5 {
+			t.Fatalf("Uniform[float32](1, 1.5) = %v", v)
+		}
+		if v := Uniform[myFloat](-1, 0); v < -1 || v >= 0 {
+			t.Fatalf("Uniform[myFloat](-1, 0) = %v", v)
+		}
+		if v := Uniform(-math.MaxFloat64, math.MaxFloat64); math.IsInf(v, 0) || math.IsNaN(v) {
+			t.Fatalf("Uniform(-MaxFloat64, MaxFloat64) = %v", v)
+		}
+	}
+	for _, b := range [][2]float64{{1, 1}, {2, 1}, {0, math.Inf(1)}, {math.NaN(), 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Uniform(%v, %v) did not panic", b[0], b[1])
+				}
+			}()
+			Uniform(b[0], b[1])
+		}()
+	}
+}
@@ -0,0 +1,40 @@
+package rnd
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand/v2"
+	"slices"
+)
+
+// NewFromBytes returns a new Rand, whose stream is a pure function of data.
+// It is meant for fuzz tests, to drive code using an injected generator
+// with fuzzer-provided bytes.
+//
+// The generator first returns the bytes of data directly, eight at a time
+// as little-endian integers, so the fuzzer has direct control over the
+// initial values. Once data is exhausted, it continues with a ChaCha8 stream
+// seeded by a hash of data.
+func NewFromBytes(data []byte) *Rand {
+	seed := sha256.Sum256(data)
+	return FromSource(&bytesSource{
+		data: slices.Clone(data),
+		next: newSource(ChaCha8, seed),
+	})
+}
+
+// bytesSource is the rand.Source used by NewFromBytes.
+type bytesSource struct {
+	data []byte
+	next rand.Source
+}
+
+func (s *bytesSource) Uint64() uint64 {
+	if len(s.data) == 0 {
+		return s.next.Uint64()
+	}
+	var b [8]byte
+	n := copy(b[:], s.data)
+	s.data = s.data[n:]
+	return binary.LittleEndian.Uint64(b[:])
+}
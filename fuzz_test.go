@@ -0,0 +1,36 @@
+package rnd
+
+import "testing"
+
+func TestNewFromBytes(t *testing.T) {
+	r := NewFromBytes([]byte{1, 0, 0, 0, 0, 0, 0, 0, 2})
+	if v := r.Uint64(); v != 1 {
+		t.Errorf("first Uint64() = %d, want 1", v)
+	}
+	if v := r.Uint64(); v != 2 {
+		t.Errorf("second Uint64() = %d, want 2", v)
+	}
+	a, b := r.Uint64(), NewFromBytes([]byte{1, 0, 0, 0, 0, 0, 0, 0, 3})
+	b.Uint64()
+	b.Uint64()
+	if a == b.Uint64() {
+		t.Error("stream after exhausting data does not depend on data")
+	}
+
+	x, y := NewFromBytes(nil), NewFromBytes(nil)
+	for i := 0; i < 10; i++ {
+		if v, w := x.Intn(100), y.Intn(100); v != w {
+			t.Fatalf("NewFromBytes(nil) returned different streams: %d != %d", v, w)
+		}
+	}
+}
+
+func FuzzNewFromBytes(f *testing.F) {
+	f.Add([]byte("hello"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := NewFromBytes(data)
+		if n := r.Intn(10); n < 0 || n >= 10 {
+			t.Fatalf("Intn(10) = %d", n)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package rnd
+
+import "sync/atomic"
+
+// gateOne is the threshold of a Gate with rate 1. Allow compares 63-bit
+// values against the threshold, so rate 1 can be represented exactly.
+const gateOne = 1 << 63
+
+// Gate allows events with a probability, which can be changed at any time.
+// It is meant for sampling and feature percentages, which should be tunable
+// while a program is running. It is safe for concurrent use.
+//
+// The zero Gate never allows an event.
+type Gate struct {
+	threshold atomic.Uint64
+}
+
+// NewGate returns a Gate with the given rate. It panics if rate is not in
+// [0,1].
+func NewGate(rate float64) *Gate {
+	g := new(Gate)
+	g.SetRate(rate)
+	return g
+}
+
+// SetRate sets the probability with which g allows an event. It takes effect
+// for all subsequent calls to Allow. SetRate panics if rate is not in [0,1].
+func (g *Gate) SetRate(rate float64) {
+	if !(rate >= 0 && rate <= 1) {
+		panic("invalid rate for Gate")
+	}
+	g.threshold.Store(uint64(rate * gateOne))
+}
+
+// Rate returns the probability with which g allows an event.
+func (g *Gate) Rate() float64 {
+	return float64(g.threshold.Load()) / gateOne
+}
+
+// Allow reports whether an event should be allowed, which is true with
+// probability g.Rate().
+//
+// Allow does not synchronize, except for loading the rate. It draws from
+// a pooled generator, as WithRand does, and none at all if the rate is 0 or 1.
+func (g *Gate) Allow() bool {
+	t := g.threshold.Load()
+	switch t {
+	case 0:
+		return false
+	case gateOne:
+		return true
+	}
+	r := getRand()
+	v := r.Uint64() >> 1
+	putRand(r)
+	return v < t
+}
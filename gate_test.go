@@ -0,0 +1,68 @@
+package rnd
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGate(t *testing.T) {
+	const n = 10000
+	var zero Gate
+	if zero.Allow() || zero.Rate() != 0 {
+		t.Errorf("zero Gate allowed event or has rate %v", zero.Rate())
+	}
+	g := NewGate(1)
+	for range 100 {
+		if !g.Allow() {
+			t.Fatalf("Gate with rate 1 did not allow event")
+		}
+	}
+	for _, rate := range []float64{0.1, 0.5, 0.9} {
+		g.SetRate(rate)
+		if g.Rate() != rate {
+			t.Errorf("Rate() = %v after SetRate(%v)", g.Rate(), rate)
+		}
+		var allowed int
+		for range n {
+			if g.Allow() {
+				allowed++
+			}
+		}
+		if got := float64(allowed) / n; got < rate-0.03 || got > rate+0.03 {
+			t.Errorf("Gate with rate %v allowed events with probability %v", rate, got)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetRate(1.5) did not panic")
+		}
+	}()
+	g.SetRate(1.5)
+}
+
+func TestGateConcurrent(t *testing.T) {
+	g := NewGate(0.5)
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				g.Allow()
+				if i == 0 {
+					g.SetRate(Float64())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkGate(b *testing.B) {
+	g := NewGate(0.01)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.Allow()
+		}
+	})
+}
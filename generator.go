@@ -0,0 +1,57 @@
+package rnd
+
+// Generator is the method set shared by *Rand and the package-level
+// functions. Code that wants to be testable deterministically can accept a
+// Generator, using Global in production and a generator from package
+// gonih.org/rnd/rndtest in tests.
+type Generator interface {
+	Int63() int64
+	Uint32() uint32
+	Uint64() uint64
+	Int31() int32
+	Int() int
+	Int63n(n int64) int64
+	Uint64n(n uint64) uint64
+	Int31n(n int32) int32
+	Intn(n int) int
+	Float64() float64
+	Float32() float32
+	Perm(n int) []int
+	Shuffle(n int, swap func(i, j int))
+	Read(p []byte) (n int, err error)
+	NormFloat64() float64
+	ExpFloat64() float64
+}
+
+var (
+	_ Generator = (*Rand)(nil)
+	_ Generator = globalGenerator{}
+)
+
+// Global returns a Generator using the package-level functions. Unlike a
+// *Rand, it is safe for concurrent use.
+func Global() Generator {
+	return globalGenerator{}
+}
+
+type globalGenerator struct{}
+
+func (globalGenerator) Int63() int64                     { return Int63() }
+func (globalGenerator) Uint32() uint32                   { return Uint32() }
+func (globalGenerator) Uint64() uint64                   { return Uint64() }
+func (globalGenerator) Int31() int32                     { return Int31() }
+func (globalGenerator) Int() int                         { return Int() }
+func (globalGenerator) Int63n(n int64) int64             { return Int63n(n) }
+func (globalGenerator) Uint64n(n uint64) uint64          { return Uint64n(n) }
+func (globalGenerator) Int31n(n int32) int32             { return Int31n(n) }
+func (globalGenerator) Intn(n int) int                   { return Intn(n) }
+func (globalGenerator) Float64() float64                 { return Float64() }
+func (globalGenerator) Float32() float32                 { return Float32() }
+func (globalGenerator) Perm(n int) []int                 { return Perm(n) }
+func (globalGenerator) Read(p []byte) (n int, err error) { return Read(p) }
+func (globalGenerator) NormFloat64() float64             { return NormFloat64() }
+func (globalGenerator) ExpFloat64() float64              { return ExpFloat64() }
+
+func (globalGenerator) Shuffle(n int, swap func(i, j int)) {
+	ShuffleFunc(n, swap)
+}
@@ -0,0 +1,183 @@
+package rnd
+
+import "math"
+
+// UnitVector2 returns a vector uniformly distributed on the unit circle.
+func UnitVector2() (x, y float64) {
+	y, x = math.Sincos(2 * math.Pi * Float64())
+	return x, y
+}
+
+// UnitVector3 returns a vector uniformly distributed on the unit sphere.
+func UnitVector3() (x, y, z float64) {
+	// By Archimedes' hat-box theorem, z is uniformly distributed in [-1,1].
+	z = 2*Float64() - 1
+	r := math.Sqrt(1 - z*z)
+	y, x = math.Sincos(2 * math.Pi * Float64())
+	return r * x, r * y, z
+}
+
+// OnCircle returns a point uniformly distributed on the circle of radius r
+// around the origin.
+func OnCircle(r float64) (x, y float64) {
+	x, y = UnitVector2()
+	return r * x, r * y
+}
+
+// InDisk returns a point uniformly distributed in the disk of radius r around
+// the origin.
+func InDisk(r float64) (x, y float64) {
+	// The area within radius s grows with s², so the radius needs to be
+	// distributed like the square root of a uniform variable.
+	return OnCircle(r * math.Sqrt(Float64()))
+}
+
+// OnSphere returns a point uniformly distributed on the sphere of radius r
+// around the origin.
+func OnSphere(r float64) (x, y, z float64) {
+	x, y, z = UnitVector3()
+	return r * x, r * y, r * z
+}
+
+// InSphere returns a point uniformly distributed in the ball of radius r
+// around the origin.
+func InSphere(r float64) (x, y, z float64) {
+	// The volume within radius s grows with s³, so the radius needs to be
+	// distributed like the cube root of a uniform variable.
+	return OnSphere(r * math.Cbrt(Float64()))
+}
+
+// Quaternion returns a uniformly distributed unit quaternion, as
+// [w, x, y, z]. It represents a uniformly distributed rotation in three
+// dimensions.
+//
+// See Shoemake, "Uniform random rotations", Graphics Gems III, 1992.
+func Quaternion() [4]float64 {
+	u1 := Float64()
+	s1, c1 := math.Sincos(2 * math.Pi * Float64())
+	s2, c2 := math.Sincos(2 * math.Pi * Float64())
+	r1, r2 := math.Sqrt(1-u1), math.Sqrt(u1)
+	return [4]float64{r2 * c2, r1 * s1, r1 * c1, r2 * s2}
+}
+
+// Rotation returns a uniformly distributed rotation matrix in three
+// dimensions.
+func Rotation() [3][3]float64 {
+	q := Quaternion()
+	w, x, y, z := q[0], q[1], q[2], q[3]
+	return [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// Simplex returns a point uniformly distributed on the standard
+// (n-1)-simplex, that is n non-negative numbers summing to 1. It panics if
+// n <= 0.
+func Simplex(n int) []float64 {
+	if n <= 0 {
+		panic("invalid argument to Simplex")
+	}
+	// Normalized exponential variables are uniformly distributed on the
+	// simplex.
+	p := make([]float64, n)
+	var sum float64
+	for i := range p {
+		p[i] = ExpFloat64()
+		sum += p[i]
+	}
+	for i := range p {
+		p[i] /= sum
+	}
+	return p
+}
+
+// Orthogonal returns a random orthogonal n×n matrix, distributed according to
+// the Haar measure on O(n). It panics if n <= 0.
+//
+// See Mezzadri, "How to generate random matrices from the classical compact
+// groups", 2007.
+func Orthogonal(n int) [][]float64 {
+	if n <= 0 {
+		panic("invalid argument to Orthogonal")
+	}
+	// We orthonormalize the columns of a matrix of independent standard
+	// normal variables, using modified Gram-Schmidt. This is a QR
+	// decomposition in which R has a positive diagonal, which is exactly the
+	// sign correction needed for Q to be Haar distributed.
+	cols := make([][]float64, n)
+	for j := range cols {
+		cols[j] = make([]float64, n)
+		for i := range cols[j] {
+			cols[j][i] = NormFloat64()
+		}
+	}
+	for j, c := range cols {
+		for _, q := range cols[:j] {
+			var dot float64
+			for i := range c {
+				dot += q[i] * c[i]
+			}
+			for i := range c {
+				c[i] -= dot * q[i]
+			}
+		}
+		var norm float64
+		for _, v := range c {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		for i := range c {
+			c[i] /= norm
+		}
+	}
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		for j := range m[i] {
+			m[i][j] = cols[j][i]
+		}
+	}
+	return m
+}
+
+// LatLong returns a point chosen uniformly on the surface of a sphere, like
+// the Earth, as latitude in [-90,90] and longitude in [-180,180) degrees.
+// Latitudes are not uniform: they are concentrated towards the equator, as
+// there is more surface area there.
+func LatLong() (lat, long float64) {
+	return LatLongIn(-90, 90, -180, 180)
+}
+
+// LatLongIn returns a point chosen uniformly on the part of the surface of a
+// sphere within the given bounds, as latitude and longitude in degrees.
+// Latitude is in [minLat,maxLat], longitude in [minLong,maxLong). If
+// minLong > maxLong, the box crosses the antimeridian, so longitude is in
+// [minLong,180) or [-180,maxLong).
+//
+// LatLongIn panics if the latitudes are not in [-90,90], the longitudes are
+// not in [-180,180], minLat > maxLat, or minLong == maxLong.
+func LatLongIn(minLat, maxLat, minLong, maxLong float64) (lat, long float64) {
+	if !(-90 <= minLat && minLat <= maxLat && maxLat <= 90) ||
+		!(-180 <= minLong && minLong <= 180 && -180 <= maxLong && maxLong <= 180) ||
+		minLong == maxLong {
+		panic("invalid arguments to LatLongIn")
+	}
+	const rad = math.Pi / 180
+	// The surface area between two latitudes is proportional to the
+	// difference of their sines.
+	lo, hi := math.Sin(minLat*rad), math.Sin(maxLat*rad)
+	lat = math.Asin(lo+Float64()*(hi-lo)) / rad
+	lat = max(minLat, min(maxLat, lat))
+
+	width := maxLong - minLong
+	if width < 0 {
+		width += 360
+	}
+	long = minLong + Float64()*width
+	if long >= 180 {
+		long -= 360
+	}
+	return lat, long
+}
@@ -0,0 +1,145 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitVector(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		if x, y := UnitVector2(); math.Abs(math.Hypot(x, y)-1) > 1e-12 {
+			t.Fatalf("UnitVector2() = (%v, %v), has length %v", x, y, math.Hypot(x, y))
+		}
+		if x, y, z := UnitVector3(); math.Abs(math.Sqrt(x*x+y*y+z*z)-1) > 1e-12 {
+			t.Fatalf("UnitVector3() = (%v, %v, %v), has length %v", x, y, z, math.Sqrt(x*x+y*y+z*z))
+		}
+	}
+}
+
+func TestDiskAndSphere(t *testing.T) {
+	const n, r = 10000, 2.0
+	var inner2, inner3 int
+	for i := 0; i < n; i++ {
+		if x, y := OnCircle(r); math.Abs(math.Hypot(x, y)-r) > 1e-12 {
+			t.Fatalf("OnCircle(%v) = (%v, %v)", r, x, y)
+		}
+		if x, y, z := OnSphere(r); math.Abs(math.Sqrt(x*x+y*y+z*z)-r) > 1e-12 {
+			t.Fatalf("OnSphere(%v) = (%v, %v, %v)", r, x, y, z)
+		}
+		x, y := InDisk(r)
+		d := math.Hypot(x, y)
+		if d > r {
+			t.Fatalf("InDisk(%v) = (%v, %v)", r, x, y)
+		}
+		if d < r/2 {
+			inner2++
+		}
+		x, y, z := InSphere(r)
+		d = math.Sqrt(x*x + y*y + z*z)
+		if d > r {
+			t.Fatalf("InSphere(%v) = (%v, %v, %v)", r, x, y, z)
+		}
+		if d < r/2 {
+			inner3++
+		}
+	}
+	// The inner half of the radius contains 1/4 of the area of the disk and
+	// 1/8 of the volume of the ball.
+	if math.Abs(float64(inner2)/n-0.25) > 0.03 {
+		t.Errorf("InDisk returned %d of %d points in the inner half, want about %d", inner2, n, n/4)
+	}
+	if math.Abs(float64(inner3)/n-0.125) > 0.03 {
+		t.Errorf("InSphere returned %d of %d points in the inner half, want about %d", inner3, n, n/8)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		q := Quaternion()
+		if n := math.Sqrt(q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3]); math.Abs(n-1) > 1e-12 {
+			t.Fatalf("Quaternion() = %v, has norm %v", q, n)
+		}
+		m := Rotation()
+		// m must be orthogonal with determinant 1.
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				var dot float64
+				for l := 0; l < 3; l++ {
+					dot += m[j][l] * m[k][l]
+				}
+				if want := float64(b2i(j == k)); math.Abs(dot-want) > 1e-12 {
+					t.Fatalf("Rotation() = %v, is not orthogonal", m)
+				}
+			}
+		}
+		det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) - m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) + m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+		if math.Abs(det-1) > 1e-12 {
+			t.Fatalf("Rotation() = %v, has determinant %v", m, det)
+		}
+	}
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestSimplex(t *testing.T) {
+	for _, n := range []int{1, 2, 10} {
+		p := Simplex(n)
+		var sum float64
+		for _, v := range p {
+			if v < 0 {
+				t.Fatalf("Simplex(%d) = %v, has negative component", n, p)
+			}
+			sum += v
+		}
+		if len(p) != n || math.Abs(sum-1) > 1e-12 {
+			t.Fatalf("Simplex(%d) = %v, sums to %v", n, p, sum)
+		}
+	}
+}
+
+func TestOrthogonal(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 20} {
+		m := Orthogonal(n)
+		for j := 0; j < n; j++ {
+			for k := 0; k < n; k++ {
+				var dot float64
+				for l := 0; l < n; l++ {
+					dot += m[j][l] * m[k][l]
+				}
+				if want := float64(b2i(j == k)); math.Abs(dot-want) > 1e-9 {
+					t.Fatalf("Orthogonal(%d) is not orthogonal: row %d · row %d = %v", n, j, k, dot)
+				}
+			}
+		}
+	}
+}
+
+func TestLatLong(t *testing.T) {
+	const n = 10000
+	north := 0
+	for i := 0; i < n; i++ {
+		lat, long := LatLong()
+		if lat < -90 || lat > 90 || long < -180 || long >= 180 {
+			t.Fatalf("LatLong() = %v, %v", lat, long)
+		}
+		// Latitudes above 30° cover a quarter of the surface.
+		if lat > 30 {
+			north++
+		}
+	}
+	if f := float64(north) / n; math.Abs(f-0.25) > 0.02 {
+		t.Errorf("LatLong returned latitudes above 30° with frequency %v, want 0.25", f)
+	}
+
+	for i := 0; i < 1000; i++ {
+		lat, long := LatLongIn(10, 20, 170, -170)
+		if lat < 10 || lat > 20 || (long < 170 && long >= -170) {
+			t.Fatalf("LatLongIn(10, 20, 170, -170) = %v, %v", lat, long)
+		}
+	}
+}
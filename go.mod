@@ -1,5 +1,3 @@
 module gonih.org/rnd
 
-go 1.18
-
-require golang.org/x/exp v0.0.0-20220218215828-6cf2b201936e // indirect
+go 1.23
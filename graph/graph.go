@@ -0,0 +1,135 @@
+// Package graph generates random graphs and trees.
+//
+// Graphs are returned as adjacency lists: the graph has len(adj) vertices,
+// numbered from 0, and adj[v] lists the neighbors of v in increasing order.
+// All graphs are undirected and simple, so every edge appears in the lists of
+// both its endpoints and there are no loops or multi-edges.
+//
+// The generators use the global source of package gonih.org/rnd, so they can
+// be made reproducible for tests using its debug seed.
+package graph
+
+import (
+	"math"
+	"slices"
+
+	"gonih.org/rnd"
+)
+
+// GNP returns an Erdős–Rényi random graph G(n,p) with n vertices, where every
+// possible edge is included independently with probability p. It runs in time
+// proportional to the number of vertices and edges generated, so it is
+// efficient for sparse graphs. GNP panics if n < 0 or p is not in [0,1].
+func GNP(n int, p float64) [][]int {
+	if n < 0 || !(p >= 0 && p <= 1) {
+		panic("invalid argument to GNP")
+	}
+	adj := make([][]int, n)
+	if p == 0 {
+		return adj
+	}
+	if p == 1 {
+		for v := range n {
+			for w := range v {
+				addEdge(adj, v, w)
+			}
+		}
+		return adj
+	}
+	// Batagelj and Brandes, "Efficient generation of large random networks".
+	// Instead of deciding on every edge, we skip a geometrically distributed
+	// number of edges to get to the next one included.
+	lp := math.Log1p(-p)
+	v, w := 1, -1
+	for v < n {
+		skip := math.Floor(math.Log1p(-rnd.Float64()) / lp)
+		if skip >= float64(n)*float64(n) {
+			break
+		}
+		w += 1 + int(skip)
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			addEdge(adj, v, w)
+		}
+	}
+	sortLists(adj)
+	return adj
+}
+
+// GNM returns an Erdős–Rényi random graph G(n,m), chosen uniformly among all
+// graphs with n vertices and m edges. It panics if n < 0, m < 0 or m exceeds
+// the number of possible edges n·(n-1)/2.
+func GNM(n, m int) [][]int {
+	if n < 0 || m < 0 || (n > 1 && m > n*(n-1)/2) || (n <= 1 && m > 0) {
+		panic("invalid argument to GNM")
+	}
+	adj := make([][]int, n)
+	if m == 0 {
+		return adj
+	}
+	for _, e := range rnd.PickK(n*(n-1)/2, m) {
+		// Edges are numbered by their larger endpoint v, which has v edges to
+		// smaller vertices.
+		v := int((1 + math.Sqrt(1+8*float64(e))) / 2)
+		for v*(v-1)/2 > e {
+			v--
+		}
+		for (v+1)*v/2 <= e {
+			v++
+		}
+		addEdge(adj, v, e-v*(v-1)/2)
+	}
+	sortLists(adj)
+	return adj
+}
+
+// BarabasiAlbert returns a random scale-free graph with n vertices, generated
+// by the Barabási–Albert preferential attachment model. It starts with a
+// complete graph on m+1 vertices. Every further vertex is connected to m
+// distinct existing vertices, chosen with probability proportional to their
+// degree. BarabasiAlbert panics if m < 1 or n <= m.
+func BarabasiAlbert(n, m int) [][]int {
+	if m < 1 || n <= m {
+		panic("invalid argument to BarabasiAlbert")
+	}
+	adj := make([][]int, n)
+	// ends contains every vertex once for each edge it is incident to, so
+	// drawing uniformly from it is drawing proportional to degree.
+	ends := make([]int, 0, 2*m*n)
+	for v := range m + 1 {
+		for w := range v {
+			addEdge(adj, v, w)
+			ends = append(ends, v, w)
+		}
+	}
+	targets := make([]int, 0, m)
+	for v := m + 1; v < n; v++ {
+		targets = targets[:0]
+		for len(targets) < m {
+			w := ends[rnd.Intn(len(ends))]
+			if !slices.Contains(targets, w) {
+				targets = append(targets, w)
+			}
+		}
+		for _, w := range targets {
+			addEdge(adj, v, w)
+			ends = append(ends, v, w)
+		}
+	}
+	sortLists(adj)
+	return adj
+}
+
+func addEdge(adj [][]int, v, w int) {
+	adj[v] = append(adj[v], w)
+	adj[w] = append(adj[w], v)
+}
+
+func sortLists(adj [][]int) {
+	for _, l := range adj {
+		slices.Sort(l)
+	}
+}
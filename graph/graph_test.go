@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// checkGraph checks that adj is a valid simple undirected graph on n vertices
+// and returns its number of edges.
+func checkGraph(t *testing.T, name string, adj [][]int, n int) int {
+	t.Helper()
+	if len(adj) != n {
+		t.Fatalf("%s: got %d vertices, want %d", name, len(adj), n)
+	}
+	var deg int
+	for v, l := range adj {
+		if !slices.IsSorted(l) {
+			t.Fatalf("%s: adj[%d] = %v is not sorted", name, v, l)
+		}
+		for i, w := range l {
+			if w == v || w < 0 || w >= n {
+				t.Fatalf("%s: invalid edge %d-%d", name, v, w)
+			}
+			if i > 0 && l[i-1] == w {
+				t.Fatalf("%s: duplicate edge %d-%d", name, v, w)
+			}
+			if _, ok := slices.BinarySearch(adj[w], v); !ok {
+				t.Fatalf("%s: edge %d-%d is missing from adj[%d]", name, v, w, w)
+			}
+		}
+		deg += len(l)
+	}
+	return deg / 2
+}
+
+func TestGNP(t *testing.T) {
+	const n = 300
+	for _, p := range []float64{0, 0.01, 0.3, 1} {
+		adj := GNP(n, p)
+		m := checkGraph(t, "GNP", adj, n)
+		want := p * n * (n - 1) / 2
+		if math.Abs(float64(m)-want) > 5*math.Sqrt(want*(1-p))+1e-9 {
+			t.Errorf("GNP(%d, %v) has %d edges, want about %v", n, p, m, want)
+		}
+	}
+	if adj := GNP(0, 0.5); len(adj) != 0 {
+		t.Errorf("GNP(0, 0.5) = %v, want empty graph", adj)
+	}
+}
+
+func TestGNM(t *testing.T) {
+	for _, tc := range []struct{ n, m int }{{0, 0}, {1, 0}, {2, 1}, {10, 45}, {100, 250}} {
+		adj := GNM(tc.n, tc.m)
+		if m := checkGraph(t, "GNM", adj, tc.n); m != tc.m {
+			t.Errorf("GNM(%d, %d) has %d edges", tc.n, tc.m, m)
+		}
+	}
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	const n, m = 1000, 3
+	adj := BarabasiAlbert(n, m)
+	if e := checkGraph(t, "BarabasiAlbert", adj, n); e != m*(m+1)/2+(n-m-1)*m {
+		t.Errorf("BarabasiAlbert(%d, %d) has %d edges", n, m, e)
+	}
+	// Preferential attachment creates hubs, with degrees far above those of
+	// G(n,p) graphs with the same mean degree.
+	var maxDeg int
+	for _, l := range adj {
+		maxDeg = max(maxDeg, len(l))
+	}
+	if maxDeg < 30 {
+		t.Errorf("maximum degree of BarabasiAlbert(%d, %d) is %d, want a hub", n, m, maxDeg)
+	}
+}
+
+func TestPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"GNP(-1, 0)":            func() { GNP(-1, 0) },
+		"GNP(1, 2)":             func() { GNP(1, 2) },
+		"GNM(3, 4)":             func() { GNM(3, 4) },
+		"GNM(1, 1)":             func() { GNM(1, 1) },
+		"BarabasiAlbert(3, 3)":  func() { BarabasiAlbert(3, 3) },
+		"BarabasiAlbert(10, 0)": func() { BarabasiAlbert(10, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
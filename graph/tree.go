@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"strconv"
+
+	"gonih.org/rnd"
+)
+
+// Tree returns a random rooted tree with n vertices, in which every vertex
+// has at most maxFanout children. The result lists the children of every
+// vertex, in increasing order. Vertex 0 is the root and every other vertex
+// has a smaller number than its children.
+//
+// Every vertex after the root is attached to a uniformly chosen earlier vertex
+// with fewer than maxFanout children, so trees are built like a random
+// recursive tree. Tree panics if n < 0, or if maxFanout < 1 and n > 1.
+func Tree(n, maxFanout int) [][]int {
+	if n < 0 || (maxFanout < 1 && n > 1) {
+		panic("invalid argument to Tree")
+	}
+	children := make([][]int, n)
+	// open contains all vertices with fewer than maxFanout children.
+	open := make([]int, 0, n)
+	if n > 0 {
+		open = append(open, 0)
+	}
+	for v := 1; v < n; v++ {
+		i := rnd.Intn(len(open))
+		p := open[i]
+		children[p] = append(children[p], v)
+		if len(children[p]) == maxFanout {
+			open[i] = open[len(open)-1]
+			open = open[:len(open)-1]
+		}
+		open = append(open, v)
+	}
+	return children
+}
+
+// Node is a vertex of a labeled tree.
+type Node struct {
+	// Name is the label of the vertex. Names of siblings are distinct.
+	Name string
+	// Children are the children of the vertex. It is empty for leaves.
+	Children []*Node
+}
+
+// LabeledTree returns a random tree like Tree, with human-friendly names, as
+// returned by rnd.Petname. It is useful to generate directory-like
+// hierarchies. LabeledTree returns nil if n == 0 and panics under the same
+// conditions as Tree.
+func LabeledTree(n, maxFanout int) *Node {
+	children := Tree(n, maxFanout)
+	if n == 0 {
+		return nil
+	}
+	nodes := make([]*Node, n)
+	for v := range nodes {
+		nodes[v] = new(Node)
+	}
+	nodes[0].Name = rnd.Petname(2)
+	for v, cs := range children {
+		seen := make(map[string]bool, len(cs))
+		for _, c := range cs {
+			name := rnd.Petname(2)
+			for i := 2; seen[name]; i++ {
+				name = rnd.Petname(2) + "-" + strconv.Itoa(i)
+			}
+			seen[name] = true
+			nodes[c].Name = name
+			nodes[v].Children = append(nodes[v].Children, nodes[c])
+		}
+	}
+	return nodes[0]
+}
@@ -0,0 +1,55 @@
+package graph
+
+import "testing"
+
+func TestTree(t *testing.T) {
+	for _, tc := range []struct{ n, fanout int }{{0, 0}, {1, 0}, {2, 1}, {100, 1}, {1000, 3}, {1000, 1000}} {
+		children := Tree(tc.n, tc.fanout)
+		if len(children) != tc.n {
+			t.Fatalf("len(Tree(%d, %d)) = %d", tc.n, tc.fanout, len(children))
+		}
+		hasParent := make([]bool, tc.n)
+		for v, cs := range children {
+			if len(cs) > tc.fanout {
+				t.Fatalf("Tree(%d, %d): vertex %d has %d children", tc.n, tc.fanout, v, len(cs))
+			}
+			for i, c := range cs {
+				if c <= v || c >= tc.n || hasParent[c] || (i > 0 && cs[i-1] >= c) {
+					t.Fatalf("Tree(%d, %d): invalid children %v of vertex %d", tc.n, tc.fanout, cs, v)
+				}
+				hasParent[c] = true
+			}
+		}
+		for v := 1; v < tc.n; v++ {
+			if !hasParent[v] {
+				t.Fatalf("Tree(%d, %d): vertex %d has no parent", tc.n, tc.fanout, v)
+			}
+		}
+	}
+}
+
+func TestLabeledTree(t *testing.T) {
+	if n := LabeledTree(0, 1); n != nil {
+		t.Errorf("LabeledTree(0, 1) = %v, want nil", n)
+	}
+	var count int
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		count++
+		if n.Name == "" {
+			t.Error("LabeledTree generated an empty name")
+		}
+		seen := make(map[string]bool)
+		for _, c := range n.Children {
+			if seen[c.Name] {
+				t.Errorf("LabeledTree generated duplicate sibling name %q", c.Name)
+			}
+			seen[c.Name] = true
+			walk(c)
+		}
+	}
+	walk(LabeledTree(500, 50))
+	if count != 500 {
+		t.Errorf("LabeledTree(500, 50) has %d nodes", count)
+	}
+}
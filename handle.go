@@ -0,0 +1,83 @@
+package rnd
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// handles recycles the generators used by Handles and WithRand. New
+// generators are seeded from the global source.
+var handles = sync.Pool{
+	New: func() any {
+		var seed [32]byte
+		Read(seed[:])
+		return newRand(seed)
+	},
+}
+
+// recording is set, while Record is in progress.
+var recording atomic.Bool
+
+// reproducible reports whether the stream of the global source is meant to
+// be reproducible, because it is being recorded, was replaced by SetSource or
+// Replay, or was seeded by EnableDebugSeeding.
+func reproducible() bool {
+	return recording.Load() || customSource.Load() || debugSeeded.Load()
+}
+
+// getRand returns a generator from handles. If the global source is
+// reproducible, it instead returns a new generator seeded from the global
+// source, as generators from handles are shared in an unpredictable way.
+func getRand() *Rand {
+	if reproducible() {
+		var seed [32]byte
+		Read(seed[:])
+		return newRand(seed)
+	}
+	return handles.Get().(*Rand)
+}
+
+// putRand returns r to handles, unless the global source is reproducible.
+func putRand(r *Rand) {
+	if !reproducible() {
+		handles.Put(r)
+	}
+}
+
+// Handle is a generator for exclusive use by a single goroutine. It has the
+// full method set of Rand and does not do any synchronization.
+//
+// A Handle must not be used after calling Release.
+type Handle struct {
+	*Rand
+}
+
+// Acquire returns a Handle. Its generator is seeded from the global source,
+// but its stream is independent of it. The caller should call Release once
+// it is done with it, so the generator can be reused.
+//
+// Acquire is meant for long-running goroutines doing many draws, which want
+// to avoid the locking of the package-level functions.
+func Acquire() Handle {
+	return Handle{getRand()}
+}
+
+// Release returns the generator of h to the package for re-use. h must not be
+// used afterwards.
+func (h Handle) Release() {
+	if h.Rand == nil {
+		panic("rnd: Release of zero Handle")
+	}
+	putRand(h.Rand)
+}
+
+// WithRand calls fn with a generator for exclusive use during the call. fn
+// must not retain r or use it after returning.
+//
+// WithRand is cheaper than the package-level functions if fn does many draws,
+// as r does not do any synchronization.
+func WithRand(fn func(r *Rand)) {
+	r := getRand()
+	defer putRand(r)
+	fn(r)
+}
@@ -0,0 +1,44 @@
+package rnd
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestHandle(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				h := Acquire()
+				for k := 0; k < 1000; k++ {
+					if n := h.Intn(10); n < 0 || n >= 10 {
+						t.Errorf("Intn(10) = %d", n)
+					}
+				}
+				h.Release()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestHandleIndependent(t *testing.T) {
+	a, b := Acquire(), Acquire()
+	defer a.Release()
+	defer b.Release()
+	if a.Uint64() == b.Uint64() {
+		t.Fatal("Handles returned the same value")
+	}
+}
+
+func TestWithRand(t *testing.T) {
+	var x, y uint64
+	WithRand(func(r *Rand) { x = r.Uint64() })
+	WithRand(func(r *Rand) { y = r.Uint64() })
+	if x == y {
+		t.Fatal("WithRand returned the same value twice")
+	}
+}
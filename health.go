@@ -0,0 +1,66 @@
+package rnd
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// healthSamples is the number of values drawn by HealthCheck.
+const healthSamples = 16
+
+func init() {
+	// The seeds come from the runtime, which we can not check for
+	// misconfiguration in any other way. If they are broken, every generator
+	// of this package is predictable, so failing loudly is the only option.
+	a, b := newSeed(), newSeed()
+	var vals []uint64
+	for _, s := range [][32]byte{a, b} {
+		for i := 0; i < len(s); i += 8 {
+			vals = append(vals, binary.LittleEndian.Uint64(s[i:]))
+		}
+	}
+	if err := checkOutput(vals); err != nil {
+		panic("rnd: seeds from the runtime are degenerate: " + err.Error())
+	}
+}
+
+// HealthCheck draws a few values from the global source and returns an
+// error, if they are obviously not random: if a value repeats or if the
+// fraction of set bits is far from one half. A healthy source fails the check
+// with negligible probability, so an error indicates a broken source, like a
+// misbehaving source passed to SetSource.
+//
+// HealthCheck can only detect gross failures. It is not a statistical test of
+// the quality or unpredictability of the source. The values it draws are
+// consumed, so they are not returned by other functions, but they are
+// captured by Record.
+func HealthCheck() error {
+	vals := make([]uint64, healthSamples)
+	for i := range vals {
+		vals[i] = src.Uint64()
+	}
+	return checkOutput(vals)
+}
+
+// checkOutput returns an error, if vals are obviously not uniformly random.
+// For uniform values, it fails with probability less than 2⁻⁴⁰.
+func checkOutput(vals []uint64) error {
+	seen := make(map[uint64]bool, len(vals))
+	var ones int
+	for _, v := range vals {
+		if seen[v] {
+			return errors.New("rnd: source repeated a value")
+		}
+		seen[v] = true
+		ones += bits.OnesCount64(v)
+	}
+	// The number of set bits is binomially distributed. Allow eight standard
+	// deviations.
+	n := 64 * float64(len(vals))
+	if d := math.Abs(float64(ones) - n/2); d > 8*math.Sqrt(n)/2 {
+		return errors.New("rnd: source output is biased")
+	}
+	return nil
+}
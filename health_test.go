@@ -0,0 +1,27 @@
+package rnd
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// counterSource returns 1, 2, 3, … .
+type counterSource struct{ n atomic.Uint64 }
+
+func (s *counterSource) Uint64() uint64 { return s.n.Add(1) }
+
+func TestHealthCheck(t *testing.T) {
+	if err := HealthCheck(); err != nil {
+		t.Fatalf("HealthCheck() = %v with default source", err)
+	}
+	defer SetSource(nil)
+	for name, s := range map[string]interface{ Uint64() uint64 }{
+		"constant": constSource(42),
+		"counter":  new(counterSource),
+	} {
+		SetSource(s)
+		if err := HealthCheck(); err == nil {
+			t.Errorf("HealthCheck() = <nil> with %s source", name)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package rnd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// machineIDFiles are checked, in order, for a stable machine identifier.
+var machineIDFiles = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+// hostIdentity returns a stable identifier of the host. It uses the machine
+// ID of systemd or D-Bus, if available, and the host name otherwise.
+var hostIdentity = sync.OnceValues(func() ([]byte, error) {
+	for _, f := range machineIDFiles {
+		if b, err := os.ReadFile(f); err == nil {
+			if b = bytes.TrimSpace(b); len(b) > 0 {
+				return b, nil
+			}
+		}
+	}
+	h, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("rnd: can not determine host identity: %w", err)
+	}
+	if h == "" {
+		return nil, errors.New("rnd: can not determine host identity: empty host name")
+	}
+	return []byte("hostname:" + h), nil
+})
+
+// NewHostStable returns a new Rand, whose stream is a pure function of the
+// identity of the host and namespace. It is meant for randomized per-host
+// decisions, like staggering maintenance windows, which must stay the same
+// across restarts, but differ between hosts. Different namespaces give
+// statistically independent streams.
+//
+// The identity of the host is its machine ID (/etc/machine-id on most Linux
+// systems), or its host name, if there is none. Hosts cloned from the same
+// image without regenerating the machine ID get the same streams. NewHostStable
+// panics if neither can be determined.
+func NewHostStable(namespace string) *Rand {
+	id, err := hostIdentity()
+	if err != nil {
+		panic(err)
+	}
+	salt := append([]byte("gonih.org/rnd.NewHostStable\x00"), id...)
+	return ForKeySalted([]byte(namespace), salt)
+}
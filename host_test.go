@@ -0,0 +1,18 @@
+package rnd
+
+import "testing"
+
+func TestNewHostStable(t *testing.T) {
+	if _, err := hostIdentity(); err != nil {
+		t.Skip(err)
+	}
+	a, b := NewHostStable("maintenance"), NewHostStable("maintenance")
+	for range 10 {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("NewHostStable is not stable: %#x != %#x", x, y)
+		}
+	}
+	if NewHostStable("other").Uint64() == NewHostStable("maintenance").Uint64() {
+		t.Error("different namespaces give the same stream")
+	}
+}
@@ -0,0 +1,228 @@
+package rnd
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// UUIDBytes returns a random (version 4) UUID, as defined in RFC 4122.
+func UUIDBytes() [16]byte {
+	var u [16]byte
+	Read(u[:])
+	u[6] = u[6]&0x0f | 0x40 // version 4
+	u[8] = u[8]&0x3f | 0x80 // variant 10
+	return u
+}
+
+// UUID returns a random (version 4) UUID, as defined in RFC 4122, in its
+// canonical string form.
+func UUID() string {
+	u := UUIDBytes()
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// ID128 returns a random 128-bit identifier. The probability of collisions is
+// negligible, as long as fewer than about 2^50 identifiers are generated.
+func ID128() [16]byte {
+	var id [16]byte
+	Read(id[:])
+	return id
+}
+
+// ID128String returns a random 128-bit identifier, as returned by ID128, in
+// lowercase hexadecimal.
+func ID128String() string {
+	id := ID128()
+	return hex.EncodeToString(id[:])
+}
+
+// ID256 returns a random 256-bit identifier.
+func ID256() [32]byte {
+	var id [32]byte
+	Read(id[:])
+	return id
+}
+
+// ID256String returns a random 256-bit identifier, as returned by ID256, in
+// lowercase hexadecimal.
+func ID256String() string {
+	id := ID256()
+	return hex.EncodeToString(id[:])
+}
+
+// crockford is the alphabet of Crockford's base32, used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulid is the state of the last ULID generated, to guarantee monotonicity.
+var ulid struct {
+	sync.Mutex
+	ms     uint64
+	hi, lo uint64 // 80 bits of randomness, hi only uses the low 16 bits.
+}
+
+// ULID returns a ULID, as specified by https://github.com/ulid/spec. It
+// consists of the current time in milliseconds and 80 bits of randomness.
+//
+// ULIDs returned by the same process are strictly increasing: Within the same
+// millisecond (or if the wall clock goes backwards), the random part of the
+// previous ULID is incremented instead of being drawn anew.
+func ULID() string {
+	ms := uint64(time.Now().UnixMilli())
+
+	ulid.Lock()
+	if ms <= ulid.ms {
+		ms = ulid.ms
+		var c uint64
+		ulid.lo, c = bits.Add64(ulid.lo, 1, 0)
+		ulid.hi = (ulid.hi + c) & 0xffff
+		if ulid.hi == 0 && ulid.lo == 0 {
+			// The random part overflowed, so advance to the next
+			// millisecond.
+			ms++
+			ulid.hi, ulid.lo = Uint64()&0xffff, Uint64()
+		}
+	} else {
+		ulid.hi, ulid.lo = Uint64()&0xffff, Uint64()
+	}
+	ulid.ms = ms
+	hi, lo := ms<<16|ulid.hi, ulid.lo
+	ulid.Unlock()
+
+	var b [26]byte
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(b[:])
+}
+
+// URLSafe is an alphabet for use with ID, which consists of the characters
+// of URL-safe base64 (RFC 4648). ID(21, URLSafe) is equivalent to a NanoID.
+const URLSafe = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// ID returns a random identifier of the given length, consisting of bytes
+// from alphabet. Every byte of alphabet is chosen with the same probability,
+// so to use non-ASCII characters, see String.
+//
+// ID panics if alphabet is empty or longer than 256 bytes.
+func ID(length int, alphabet string) string {
+	if len(alphabet) == 0 || len(alphabet) > 256 {
+		panic("invalid alphabet for ID")
+	}
+	// To avoid modulo bias, we mask random bytes to the smallest power of two
+	// covering the alphabet and reject values outside of it.
+	mask := byte(1<<bits.Len(uint(len(alphabet)-1)) - 1)
+	b := make([]byte, length)
+	var buf [64]byte
+	for i := 0; i < length; {
+		p := buf[:min(len(buf), 2*(length-i))]
+		Read(p)
+		for _, c := range p {
+			if c &= mask; int(c) < len(alphabet) {
+				b[i] = alphabet[c]
+				if i++; i == length {
+					break
+				}
+			}
+		}
+	}
+	return string(b)
+}
+
+// HexString returns a string of n random hexadecimal (lower case) digits.
+func HexString(n int) string {
+	const digits = "0123456789abcdef"
+	var sb strings.Builder
+	sb.Grow(n)
+	var buf [32]byte
+	for n > 0 {
+		p := buf[:min(len(buf), (n+1)/2)]
+		Read(p)
+		for _, c := range p {
+			sb.WriteByte(digits[c>>4])
+			if n--; n == 0 {
+				break
+			}
+			sb.WriteByte(digits[c&0xf])
+			n--
+		}
+	}
+	return sb.String()
+}
+
+// Token returns nBytes random bytes, encoded as unpadded URL-safe base64
+// (RFC 4648).
+func Token(nBytes int) string {
+	b := make([]byte, nBytes)
+	Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// base62 is the alphabet used by Base62Token.
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Base62Token returns a random string of alphanumeric characters, containing
+// at least as much randomness as nBytes random bytes. It is meant for systems
+// that do not accept the characters '-' and '_' produced by Token.
+func Base62Token(nBytes int) string {
+	n := int(math.Ceil(float64(8*nBytes) / math.Log2(62)))
+	return ID(n, base62)
+}
+
+// String returns a random string of n runes from alphabet. Every rune of
+// alphabet is chosen with the same probability, so a rune appearing multiple
+// times in alphabet is chosen more often.
+//
+// String panics if alphabet is empty.
+func String(n int, alphabet string) string {
+	if len(alphabet) == 0 {
+		panic("empty alphabet for String")
+	}
+	if len(alphabet) <= 256 && isASCII(alphabet) {
+		return ID(n, alphabet)
+	}
+	runes := []rune(alphabet)
+	var sb strings.Builder
+	sb.Grow(n * utf8.UTFMax)
+	for i := 0; i < n; i++ {
+		sb.WriteRune(runes[Intn(len(runes))])
+	}
+	return sb.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Text returns a random string of 26 characters from the standard base32
+// alphabet (RFC 4648), containing 130 bits of randomness. It has the same
+// shape as crypto/rand.Text, but is not cryptographically secure and thus
+// much cheaper.
+//
+// Text is meant for correlation IDs in logs and tests. The alphabet does not
+// contain characters which are easily confused, like 0 and O or 1 and I.
+func Text() string {
+	return ID(26, "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567")
+}
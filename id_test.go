@@ -0,0 +1,116 @@
+package rnd
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestUUID(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for i := 0; i < 100; i++ {
+		if u := UUID(); !re.MatchString(u) {
+			t.Fatalf("UUID() = %q, is not a valid version 4 UUID", u)
+		}
+	}
+}
+
+func TestID128(t *testing.T) {
+	if ID128() == ID128() {
+		t.Error("ID128 returned the same identifier twice")
+	}
+	if ID256() == ID256() {
+		t.Error("ID256 returned the same identifier twice")
+	}
+	re128, re256 := regexp.MustCompile(`^[0-9a-f]{32}$`), regexp.MustCompile(`^[0-9a-f]{64}$`)
+	if id := ID128String(); !re128.MatchString(id) {
+		t.Errorf("ID128String() = %q", id)
+	}
+	if id := ID256String(); !re256.MatchString(id) {
+		t.Errorf("ID256String() = %q", id)
+	}
+}
+
+func TestULID(t *testing.T) {
+	re := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+	last := ULID()
+	for i := 0; i < 1000; i++ {
+		u := ULID()
+		if !re.MatchString(u) {
+			t.Fatalf("ULID() = %q, is not a valid ULID", u)
+		}
+		if u <= last {
+			t.Fatalf("ULID() = %q, not greater than previous %q", u, last)
+		}
+		last = u
+	}
+}
+
+func TestID(t *testing.T) {
+	for _, alphabet := range []string{"x", "01", "abc", URLSafe, string(make([]byte, 256))} {
+		for _, n := range []int{0, 1, 21, 200} {
+			id := ID(n, alphabet)
+			if len(id) != n {
+				t.Fatalf("len(ID(%d, %q)) = %d, want %d", n, alphabet, len(id), n)
+			}
+			for _, c := range []byte(id) {
+				if strings.IndexByte(alphabet, c) < 0 {
+					t.Fatalf("ID(%d, %q) = %q, contains %q", n, alphabet, id, c)
+				}
+			}
+		}
+	}
+}
+
+func TestHexString(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]*$`)
+	for _, n := range []int{0, 1, 2, 15, 64, 65, 1000} {
+		if s := HexString(n); len(s) != n || !re.MatchString(s) {
+			t.Errorf("HexString(%d) = %q", n, s)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { HexString(32) }); n > 1 {
+		t.Errorf("HexString allocates %v times, want 1", n)
+	}
+}
+
+func TestToken(t *testing.T) {
+	for _, n := range []int{0, 1, 16, 32} {
+		tok := Token(n)
+		b, err := base64.RawURLEncoding.DecodeString(tok)
+		if err != nil || len(b) != n {
+			t.Errorf("Token(%d) = %q, does not decode to %d bytes: %v", n, tok, n, err)
+		}
+	}
+	re := regexp.MustCompile(`^[0-9A-Za-z]*$`)
+	for n, want := range map[int]int{0: 0, 1: 2, 16: 22, 32: 43} {
+		if tok := Base62Token(n); len(tok) != want || !re.MatchString(tok) {
+			t.Errorf("Base62Token(%d) = %q, want %d alphanumeric characters", n, tok, want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, alphabet := range []string{"x", "abc", "äöü", "日本語", "a😀"} {
+		for _, n := range []int{0, 1, 100} {
+			s := String(n, alphabet)
+			if !utf8.ValidString(s) || utf8.RuneCountInString(s) != n {
+				t.Fatalf("String(%d, %q) = %q, want %d valid runes", n, alphabet, s, n)
+			}
+			for _, r := range s {
+				if !strings.ContainsRune(alphabet, r) {
+					t.Fatalf("String(%d, %q) = %q, contains %q", n, alphabet, s, r)
+				}
+			}
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	re := regexp.MustCompile(`^[A-Z2-7]{26}$`)
+	if s := Text(); !re.MatchString(s) {
+		t.Errorf("Text() = %q", s)
+	}
+}
@@ -0,0 +1,9 @@
+// Package testhook connects package rnd to package rndtest. It allows tests
+// to relax checks of package rnd, which protect production code.
+package testhook
+
+import "sync/atomic"
+
+// LateSetSource is positive, while rnd.SetSource may be called after the
+// global source has been used.
+var LateSetSource atomic.Int32
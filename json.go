@@ -0,0 +1,108 @@
+package rnd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// JSONOpts configures JSON. The zero value of every field selects a default.
+type JSONOpts struct {
+	// MaxDepth is the maximum nesting depth of arrays and objects. It
+	// defaults to 4.
+	MaxDepth int
+	// MaxLength is the maximum number of elements of arrays and objects and
+	// of runes in strings. It defaults to 8.
+	MaxLength int
+	// KeyAlphabet is the alphabet of object keys and string values. It
+	// defaults to the lower case ASCII letters.
+	KeyAlphabet string
+	// MinNumber and MaxNumber are the range of numbers, which defaults to
+	// [-1000,1000).
+	MinNumber, MaxNumber float64
+	// Integers restricts numbers to integers.
+	Integers bool
+	// NullProb, ArrayProb and ObjectProb are the probabilities of a value
+	// being null, an array or an object. The remaining probability is split
+	// evenly among booleans, numbers and strings. If all are 0, they default
+	// to 0.1, 0.2 and 0.2. At the maximum nesting depth, no arrays or objects
+	// are generated.
+	NullProb, ArrayProb, ObjectProb float64
+}
+
+// JSON returns a random, syntactically valid JSON document, according to
+// opts.
+func JSON(opts JSONOpts) []byte {
+	if opts.MaxDepth == 0 {
+		opts.MaxDepth = 4
+	}
+	if opts.MaxLength == 0 {
+		opts.MaxLength = 8
+	}
+	if opts.KeyAlphabet == "" {
+		opts.KeyAlphabet = "abcdefghijklmnopqrstuvwxyz"
+	}
+	if opts.MinNumber == 0 && opts.MaxNumber == 0 {
+		opts.MinNumber, opts.MaxNumber = -1000, 1000
+	}
+	if opts.NullProb == 0 && opts.ArrayProb == 0 && opts.ObjectProb == 0 {
+		opts.NullProb, opts.ArrayProb, opts.ObjectProb = 0.1, 0.2, 0.2
+	}
+	var buf bytes.Buffer
+	writeJSON(&buf, &opts, 0)
+	return buf.Bytes()
+}
+
+func writeJSON(buf *bytes.Buffer, o *JSONOpts, depth int) {
+	p := Float64()
+	if p < o.NullProb {
+		buf.WriteString("null")
+		return
+	}
+	p -= o.NullProb
+	if depth < o.MaxDepth {
+		if p < o.ArrayProb {
+			buf.WriteByte('[')
+			for i, n := 0, Intn(o.MaxLength+1); i < n; i++ {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				writeJSON(buf, o, depth+1)
+			}
+			buf.WriteByte(']')
+			return
+		}
+		p -= o.ArrayProb
+		if p < o.ObjectProb {
+			buf.WriteByte('{')
+			for i, n := 0, Intn(o.MaxLength+1); i < n; i++ {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				writeJSONString(buf, o)
+				buf.WriteByte(':')
+				writeJSON(buf, o, depth+1)
+			}
+			buf.WriteByte('}')
+			return
+		}
+	}
+	switch Intn(3) {
+	case 0:
+		buf.WriteString(strconv.FormatBool(Uint32()&1 != 0))
+	case 1:
+		f := o.MinNumber + (o.MaxNumber-o.MinNumber)*Float64()
+		if o.Integers {
+			buf.WriteString(strconv.FormatFloat(float64(int64(f)), 'f', -1, 64))
+		} else {
+			buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	case 2:
+		writeJSONString(buf, o)
+	}
+}
+
+func writeJSONString(buf *bytes.Buffer, o *JSONOpts) {
+	b, _ := json.Marshal(String(Intn(o.MaxLength+1), o.KeyAlphabet))
+	buf.Write(b)
+}
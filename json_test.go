@@ -0,0 +1,26 @@
+package rnd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	tcs := []JSONOpts{
+		{},
+		{MaxDepth: 10, MaxLength: 3, ObjectProb: 0.5},
+		{KeyAlphabet: "\"\\\n\x00äö😀", Integers: true, MinNumber: -5, MaxNumber: 5},
+		{NullProb: 1},
+	}
+	for _, opts := range tcs {
+		for i := 0; i < 100; i++ {
+			b := JSON(opts)
+			if !json.Valid(b) {
+				t.Fatalf("JSON(%+v) = %s, is not valid", opts, b)
+			}
+		}
+	}
+	if b := JSON(JSONOpts{NullProb: 1}); string(b) != "null" {
+		t.Errorf("JSON(NullProb: 1) = %s, want null", b)
+	}
+}
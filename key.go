@@ -0,0 +1,68 @@
+package rnd
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// ForKey returns a new Rand, whose stream is a pure function of key. Unlike
+// Derive, the stream is the same in every process, so ForKey is meant for
+// stable decisions, like consistently assigning users to an experiment.
+//
+// ForKey always uses ChaCha8, independent of build tags. The stream is not
+// secret, as anyone knowing key can reproduce it. Use ForKeySalted with a
+// secret salt, if that is a concern.
+func ForKey(key []byte) *Rand {
+	return ForKeySalted(key, nil)
+}
+
+// ForKeySalted is like ForKey, but additionally mixes salt into the seed.
+// Different salts give statistically independent streams for the same key.
+// A per-process random salt gives the same contract as Derive.
+func ForKeySalted(key, salt []byte) *Rand {
+	return newRandOptions(keySeed(key, salt), &options{backend: ChaCha8})
+}
+
+// keySeed returns the seed used by ForKeySalted.
+func keySeed(key, salt []byte) [32]byte {
+	h := sha256.New()
+	h.Write([]byte("gonih.org/rnd.ForKey\x00"))
+	// Prefix the salt with its length, so key and salt can not be shifted
+	// into each other.
+	h.Write(binary.AppendUvarint(nil, uint64(len(salt))))
+	h.Write(salt)
+	h.Write(key)
+	var seed [32]byte
+	h.Sum(seed[:0])
+	return seed
+}
+
+// Bucket returns a bucket in [0,n) for key. Buckets are uniformly
+// distributed over keys and the same key always returns the same bucket. It
+// panics if n <= 0.
+func Bucket(key string, n int) int {
+	return BucketSalted(key, "", n)
+}
+
+// BucketSalted is like Bucket, but mixes salt into the assignment. Using
+// different salts for different experiments makes their bucket assignments
+// independent.
+func BucketSalted(key, salt string, n int) int {
+	if n <= 0 {
+		panic("invalid argument to Bucket")
+	}
+	return int(ForKeySalted([]byte(key), []byte(salt)).Uint64n(uint64(n)))
+}
+
+// Rollout reports whether key is included in a rollout to percent percent of
+// keys. The decision is stable for every key and raising percent only ever
+// adds keys. percent is clamped to [0,100].
+func Rollout(key string, percent float64) bool {
+	return RolloutSalted(key, "", percent)
+}
+
+// RolloutSalted is like Rollout, but mixes salt into the decision. Using a
+// different salt for each feature makes their rollouts independent.
+func RolloutSalted(key, salt string, percent float64) bool {
+	return ForKeySalted([]byte(key), []byte(salt)).Float64()*100 < percent
+}
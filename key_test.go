@@ -0,0 +1,85 @@
+package rnd
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestForKey(t *testing.T) {
+	a, b, c := ForKey([]byte("foo")), ForKey([]byte("foo")), ForKey([]byte("bar"))
+	d := ForKeySalted([]byte("foo"), []byte("salt"))
+	e := ForKeySalted([]byte("o"), []byte("saltfo"))
+	for i := 0; i < 10; i++ {
+		v, w, x, y, z := a.Uint64(), b.Uint64(), c.Uint64(), d.Uint64(), e.Uint64()
+		if v != w {
+			t.Fatalf("ForKey returned different streams for the same key: %d != %d", v, w)
+		}
+		if v == x || v == y || y == z {
+			t.Fatalf("ForKey returned correlated streams: %d, %d, %d, %d", v, x, y, z)
+		}
+	}
+}
+
+func TestForKeyStable(t *testing.T) {
+	// The stream must not change between processes or releases.
+	const want = 0x895e5f12a7f1201d
+	if got := ForKey([]byte("gonih.org/rnd")).Uint64(); got != want {
+		t.Errorf("ForKey(%q).Uint64() = %#x, want %#x", "gonih.org/rnd", got, uint64(want))
+	}
+}
+
+func TestBucket(t *testing.T) {
+	const n, keys = 4, 4000
+	var counts [n]int
+	for i := 0; i < keys; i++ {
+		key := strconv.Itoa(i)
+		b := Bucket(key, n)
+		if b < 0 || b >= n {
+			t.Fatalf("Bucket(%q, %d) = %d", key, n, b)
+		}
+		if b2 := Bucket(key, n); b2 != b {
+			t.Fatalf("Bucket(%q, %d) returned %d and %d", key, n, b, b2)
+		}
+		counts[b]++
+	}
+	for i, c := range counts {
+		if c < keys/n-150 || c > keys/n+150 {
+			t.Errorf("bucket %d has %d keys, want about %d", i, c, keys/n)
+		}
+	}
+
+	same := 0
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		if BucketSalted(key, "a", 1000) == BucketSalted(key, "b", 1000) {
+			same++
+		}
+	}
+	if same > 5 {
+		t.Errorf("BucketSalted with different salts agreed for %d of 100 keys", same)
+	}
+}
+
+func TestRollout(t *testing.T) {
+	const keys = 10000
+	n10, n50 := 0, 0
+	for i := 0; i < keys; i++ {
+		key := strconv.Itoa(i)
+		in10, in50 := RolloutSalted(key, "feature", 10), RolloutSalted(key, "feature", 50)
+		if in10 && !in50 {
+			t.Fatalf("key %q included at 10%% but not at 50%%", key)
+		}
+		if in10 {
+			n10++
+		}
+		if in50 {
+			n50++
+		}
+		if Rollout(key, 0) || !Rollout(key, 100) {
+			t.Fatalf("Rollout(%q, …) ignores the bounds", key)
+		}
+	}
+	if n10 < 900 || n10 > 1100 || n50 < 4800 || n50 > 5200 {
+		t.Errorf("Rollout included %d keys at 10%% and %d at 50%%, of %d", n10, n50, keys)
+	}
+}
@@ -0,0 +1,148 @@
+package rnd
+
+import "sync"
+
+// Locked wraps a *Rand, to make it safe for concurrent use.
+type Locked struct {
+	mu sync.Mutex
+	r  *Rand
+}
+
+var _ Generator = (*Locked)(nil)
+
+// NewLocked returns a Locked wrapping r. r must not be used directly
+// afterwards.
+func NewLocked(r *Rand) *Locked {
+	return &Locked{r: r}
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func (l *Locked) Int63() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int63()
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+func (l *Locked) Uint32() uint32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Uint32()
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+func (l *Locked) Uint64() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Uint64()
+}
+
+// Int31 returns a non-negative pseudo-random 31-bit integer as an int32.
+func (l *Locked) Int31() int32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int31()
+}
+
+// Int returns a non-negative pseudo-random int.
+func (l *Locked) Int() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int()
+}
+
+// Int63n returns, as an int64, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (l *Locked) Int63n(n int64) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int63n(n)
+}
+
+// Uint64n returns, as a uint64, a pseudo-random number in [0,n).
+// It panics if n == 0.
+func (l *Locked) Uint64n(n uint64) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Uint64n(n)
+}
+
+// Int31n returns, as an int32, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (l *Locked) Int31n(n int32) int32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int31n(n)
+}
+
+// Intn returns, as an int, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (l *Locked) Intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Intn(n)
+}
+
+// Float64 returns, as a float64, a pseudo-random number in [0.0,1.0).
+func (l *Locked) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
+
+// Float32 returns, as a float32, a pseudo-random number in [0.0,1.0).
+func (l *Locked) Float32() float32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float32()
+}
+
+// Perm returns, as a slice of n ints, a pseudo-random permutation of the integers [0,n).
+func (l *Locked) Perm(n int) []int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of elements. n is the number of
+// elements. Shuffle panics if n < 0. swap swaps the elements with indexes i
+// and j.
+func (l *Locked) Shuffle(n int, swap func(i, j int)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.r.Shuffle(n, swap)
+}
+
+// Read generates len(p) random bytes and writes them into p. It always returns
+// len(p) and a nil error.
+func (l *Locked) Read(p []byte) (n int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Read(p)
+}
+
+// NormFloat64 returns a normally distributed float64 in the range
+// [-math.MaxFloat64, +math.MaxFloat64] with
+// standard normal distribution (mean = 0, stddev = 1).
+func (l *Locked) NormFloat64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed float64 in the range
+// (0, +math.MaxFloat64] with an exponential distribution whose rate parameter
+// (lambda) is 1 and whose mean is 1/lambda (1).
+func (l *Locked) ExpFloat64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.ExpFloat64()
+}
+
+// Split returns a new Rand, whose stream is independent of l. It advances the
+// stream of l. See Rand.Split.
+func (l *Locked) Split() *Rand {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Split()
+}
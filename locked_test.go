@@ -0,0 +1,33 @@
+package rnd
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLocked(t *testing.T) {
+	a, b := NewLocked(Derive("foo")), Derive("foo")
+	for i := 0; i < 10; i++ {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("Locked returned different stream: %d != %d", x, y)
+		}
+	}
+
+	l := NewLocked(New())
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf [13]byte
+			for j := 0; j < 1000; j++ {
+				if n := l.Intn(10); n < 0 || n >= 10 {
+					t.Errorf("Intn(10) = %d", n)
+				}
+				l.Read(buf[:])
+				l.Shuffle(len(buf), func(i, j int) { buf[i], buf[j] = buf[j], buf[i] })
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,117 @@
+package rnd
+
+import (
+	"iter"
+	"sort"
+)
+
+// Markov is a Markov chain over states of type T. It is safe for concurrent
+// use.
+type Markov[T comparable] struct {
+	states []T
+	index  map[T]int
+	// cum contains the cumulative transition weights of every state.
+	cum [][]float64
+}
+
+// NewMarkov returns a Markov chain over states. transitions[i][j] is the
+// weight of transitioning from states[i] to states[j]. The weights of a state
+// are normalized, so they do not have to sum to 1. A state whose weights are
+// all 0 is terminal.
+//
+// NewMarkov panics if states contains duplicates, if transitions is not a
+// len(states)×len(states) matrix or if a weight is negative or NaN.
+func NewMarkov[T comparable](states []T, transitions [][]float64) *Markov[T] {
+	if len(transitions) != len(states) {
+		panic("mismatched lengths in NewMarkov")
+	}
+	m := &Markov[T]{
+		states: states,
+		index:  make(map[T]int, len(states)),
+		cum:    make([][]float64, len(states)),
+	}
+	for i, s := range states {
+		if _, ok := m.index[s]; ok {
+			panic("duplicate state in NewMarkov")
+		}
+		m.index[s] = i
+		if len(transitions[i]) != len(states) {
+			panic("mismatched lengths in NewMarkov")
+		}
+		cum := make([]float64, len(states))
+		var total float64
+		for j, w := range transitions[i] {
+			if !(w >= 0) {
+				panic("invalid weight in NewMarkov")
+			}
+			total += w
+			cum[j] = total
+		}
+		if total > 0 {
+			m.cum[i] = cum
+		}
+	}
+	return m
+}
+
+// LearnMarkov returns a Markov chain, whose transition weights are the
+// number of times each transition occurs in example. The last state of
+// example is terminal, unless it also occurs earlier.
+func LearnMarkov[T comparable](example []T) *Markov[T] {
+	var states []T
+	index := make(map[T]int)
+	for _, s := range example {
+		if _, ok := index[s]; !ok {
+			index[s] = len(states)
+			states = append(states, s)
+		}
+	}
+	transitions := make([][]float64, len(states))
+	for i := range transitions {
+		transitions[i] = make([]float64, len(states))
+	}
+	for i := 1; i < len(example); i++ {
+		transitions[index[example[i-1]]][index[example[i]]]++
+	}
+	return NewMarkov(states, transitions)
+}
+
+// Next returns a pseudo-random successor of state. It panics if state is not
+// a state of m or is terminal.
+func (m *Markov[T]) Next(state T) T {
+	i, ok := m.index[state]
+	if !ok {
+		panic("unknown state in Markov.Next")
+	}
+	next, ok := m.next(i)
+	if !ok {
+		panic("terminal state in Markov.Next")
+	}
+	return m.states[next]
+}
+
+func (m *Markov[T]) next(i int) (int, bool) {
+	cum := m.cum[i]
+	if cum == nil {
+		return 0, false
+	}
+	u := Float64() * cum[len(cum)-1]
+	// Searching for the first cumulative weight > u skips states with weight
+	// 0.
+	j := sort.Search(len(cum), func(j int) bool { return cum[j] > u })
+	return min(j, len(cum)-1), true
+}
+
+// Walk returns an iterator over a random walk on m, starting with start. The
+// walk ends, if it reaches a terminal state, otherwise it is infinite. It
+// panics if start is not a state of m.
+func (m *Markov[T]) Walk(start T) iter.Seq[T] {
+	i, ok := m.index[start]
+	if !ok {
+		panic("unknown state in Markov.Walk")
+	}
+	return func(yield func(T) bool) {
+		for i, ok := i, true; ok && yield(m.states[i]); i, ok = m.next(i) {
+		}
+	}
+}
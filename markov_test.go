@@ -0,0 +1,51 @@
+package rnd
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestMarkov(t *testing.T) {
+	m := NewMarkov([]string{"a", "b", "c"}, [][]float64{
+		{0, 3, 1},
+		{1, 0, 0},
+		{0, 0, 0},
+	})
+	var counts = make(map[string]int)
+	const n = 10000
+	for i := 0; i < n; i++ {
+		counts[m.Next("a")]++
+	}
+	if counts["a"] != 0 {
+		t.Errorf("Markov.Next made transition with weight 0")
+	}
+	if f := float64(counts["b"]) / n; math.Abs(f-0.75) > 0.03 {
+		t.Errorf("Markov.Next transitioned to b with frequency %v, want 0.75", f)
+	}
+	for i := 0; i < 100; i++ {
+		if got := m.Next("b"); got != "a" {
+			t.Fatalf(`Markov.Next("b") = %q, want "a"`, got)
+		}
+	}
+	walk := slices.Collect(m.Walk("b"))
+	if walk[len(walk)-1] != "c" {
+		t.Errorf("Markov.Walk did not end in terminal state: %v", walk)
+	}
+}
+
+func TestLearnMarkov(t *testing.T) {
+	m := LearnMarkov([]int{1, 2, 3, 1, 2, 3, 4})
+	i := 0
+	for s := range m.Walk(1) {
+		if want := i%3 + 1; s != want && s != 4 {
+			t.Fatalf("Markov.Walk yielded %d at position %d, want %d or 4", s, i, want)
+		}
+		if i++; i > 1000 {
+			break
+		}
+	}
+	if got := m.Next(1); got != 2 {
+		t.Errorf("Markov.Next(1) = %d, want 2", got)
+	}
+}
@@ -0,0 +1,80 @@
+package rnd
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+)
+
+// randMagic starts the binary encoding of a Rand.
+const randMagic = "rnd:Rand1"
+
+// errNotMarshalable is returned when marshaling a Rand with a source that
+// does not support it.
+var errNotMarshalable = errors.New("rnd: source of Rand can not be marshaled")
+
+// AppendBinary appends the state of r to b, so it can be restored later with
+// UnmarshalBinary, for example to checkpoint and resume a long-running job.
+// r then continues to produce the exact same stream.
+//
+// Only generators using one of the backends of this package directly, as
+// returned by New, Derive, Split, ForKey or SeedToken.Rand, can be marshaled.
+// For every other source, including those of generators using
+// WithBufferedBatch or WithReseedPolicy and those passed to FromSource,
+// AppendBinary returns the error errNotMarshalable ("rnd: source of Rand can
+// not be marshaled"). The global source can never be marshaled. The encoding
+// contains the secret state of the generator, so it must be protected like a
+// seed.
+func (r *Rand) AppendBinary(b []byte) ([]byte, error) {
+	var bk Backend
+	switch r.src.(type) {
+	case *rand.ChaCha8:
+		bk = ChaCha8
+	case *rand.PCG:
+		bk = PCG
+	case *xoshiro:
+		bk = Xoshiro256
+	default:
+		return nil, errNotMarshalable
+	}
+	state, err := r.src.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, randMagic...)
+	b = append(b, byte(bk), byte(r.readPos))
+	b = binary.LittleEndian.AppendUint64(b, r.readVal)
+	return append(b, state...), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. See AppendBinary.
+func (r *Rand) MarshalBinary() ([]byte, error) {
+	return r.AppendBinary(nil)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It restores a state
+// encoded by MarshalBinary, replacing the state of r. It can be called on a
+// zero Rand.
+func (r *Rand) UnmarshalBinary(data []byte) error {
+	n := len(randMagic)
+	if len(data) < n+10 || string(data[:n]) != randMagic {
+		return errors.New("rnd: invalid Rand encoding")
+	}
+	bk, pos, val := Backend(data[n]), int8(data[n+1]), binary.LittleEndian.Uint64(data[n+2:])
+	if bk > Xoshiro256 || pos < 0 || pos > 8 {
+		return errors.New("rnd: invalid Rand encoding")
+	}
+	src := newSource(bk, [32]byte{})
+	if err := src.(encoding.BinaryUnmarshaler).UnmarshalBinary(data[n+10:]); err != nil {
+		return err
+	}
+	*r = Rand{
+		src:     src,
+		r:       rand.New(src),
+		opts:    &options{backend: bk},
+		readVal: val,
+		readPos: pos,
+	}
+	return nil
+}
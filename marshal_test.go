@@ -0,0 +1,46 @@
+package rnd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	for _, b := range []Backend{ChaCha8, PCG, Xoshiro256} {
+		r := Derive("marshal", WithBackend(b))
+		r.Uint64()
+		// Leave some buffered bytes from Read.
+		r.Read(make([]byte, 3))
+		data, err := r.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%v: MarshalBinary() = %v", b, err)
+		}
+		var s Rand
+		if err := s.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%v: UnmarshalBinary() = %v", b, err)
+		}
+		p, q := make([]byte, 13), make([]byte, 13)
+		r.Read(p)
+		s.Read(q)
+		if !bytes.Equal(p, q) {
+			t.Errorf("%v: restored Rand read %x, want %x", b, q, p)
+		}
+		for range 10 {
+			if x, y := r.Uint64(), s.Uint64(); x != y {
+				t.Fatalf("%v: restored Rand produced %#x, want %#x", b, y, x)
+			}
+		}
+		if err := s.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Errorf("%v: UnmarshalBinary of truncated data succeeded", b)
+		}
+	}
+	if _, err := FromSource(constSource(1)).MarshalBinary(); err == nil {
+		t.Error("MarshalBinary with custom source succeeded")
+	}
+	if _, err := New(WithBufferedBatch(2)).MarshalBinary(); err == nil {
+		t.Error("MarshalBinary with buffered batch succeeded")
+	}
+	if err := new(Rand).UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Error("UnmarshalBinary of garbage succeeded")
+	}
+}
@@ -0,0 +1,85 @@
+package rnd
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// MCMCOption configures MCMC.
+type MCMCOption func(*mcmcOptions)
+
+type mcmcOptions struct {
+	burnIn int
+	thin   int
+}
+
+// BurnIn makes MCMC discard the first n samples, while the chain moves away
+// from its initial state. It panics if n < 0.
+func BurnIn(n int) MCMCOption {
+	if n < 0 {
+		panic("invalid argument to BurnIn")
+	}
+	return func(o *mcmcOptions) { o.burnIn = n }
+}
+
+// Thin makes MCMC only yield every k-th sample, which reduces the correlation
+// between the samples yielded. It panics if k < 1.
+func Thin(k int) MCMCOption {
+	if k < 1 {
+		panic("invalid argument to Thin")
+	}
+	return func(o *mcmcOptions) { o.thin = k }
+}
+
+// MCMC returns an infinite iterator over samples of the distribution with the
+// (unnormalized) log-density logDensity, generated by the random walk
+// Metropolis–Hastings algorithm. Every step proposes to move from the current
+// state in a normally distributed direction with standard deviation step in
+// every dimension and accepts the move with probability
+// min(1, density(proposal)/density(current)).
+//
+// Consecutive samples are correlated and the chain needs time to reach its
+// stationary distribution, see BurnIn and Thin. step should be chosen so that
+// a reasonable fraction of moves, say 25% to 50%, is accepted. Yielded slices
+// are not reused and may be retained. Ranging over the iterator again
+// continues the chain where it stopped.
+//
+// MCMC panics if init is empty, step is not positive or logDensity(init) is
+// -Inf or NaN.
+func MCMC(logDensity func(x []float64) float64, init []float64, step float64, opts ...MCMCOption) iter.Seq[[]float64] {
+	if len(init) == 0 || !(step > 0) {
+		panic("invalid argument to MCMC")
+	}
+	o := mcmcOptions{thin: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	x := slices.Clone(init)
+	lx := logDensity(x)
+	if math.IsNaN(lx) || math.IsInf(lx, -1) {
+		panic("invalid initial state for MCMC")
+	}
+	// i counts the steps of the chain, across ranges over the iterator.
+	var i int
+	return func(yield func([]float64) bool) {
+		y := make([]float64, len(x))
+		for {
+			i++
+			for j := range y {
+				y[j] = x[j] + step*NormFloat64()
+			}
+			// Compare in log space, to avoid overflow. NaN log-densities are
+			// never accepted.
+			if ly := logDensity(y); ly-lx >= 0 || math.Log(Float64OO()) < ly-lx {
+				x, y, lx = y, x, ly
+			}
+			if i <= o.burnIn || (i-o.burnIn)%o.thin != 0 {
+				continue
+			}
+			if !yield(slices.Clone(x)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMCMC(t *testing.T) {
+	// A correlated bivariate normal, with means 1 and -1, unit variances and
+	// correlation 0.5.
+	const rho = 0.5
+	logDensity := func(x []float64) float64 {
+		a, b := x[0]-1, x[1]+1
+		return -(a*a - 2*rho*a*b + b*b) / (2 * (1 - rho*rho))
+	}
+	const n = 20000
+	var s [2]float64
+	var sab float64
+	i := 0
+	for x := range MCMC(logDensity, []float64{10, 10}, 1, BurnIn(1000), Thin(5)) {
+		if len(x) != 2 {
+			t.Fatalf("MCMC yielded %v", x)
+		}
+		s[0] += x[0]
+		s[1] += x[1]
+		sab += (x[0] - 1) * (x[1] + 1)
+		if i++; i == n {
+			break
+		}
+	}
+	if m0, m1 := s[0]/n, s[1]/n; math.Abs(m0-1) > 0.1 || math.Abs(m1+1) > 0.1 {
+		t.Errorf("MCMC means = %v, %v, want 1, -1", m0, m1)
+	}
+	if c := sab / n; math.Abs(c-rho) > 0.1 {
+		t.Errorf("MCMC covariance = %v, want %v", c, rho)
+	}
+}
+
+func TestMCMCContinue(t *testing.T) {
+	var steps int
+	logDensity := func(x []float64) float64 {
+		steps++
+		return -x[0] * x[0] / 2
+	}
+	seq := MCMC(logDensity, []float64{0}, 1, BurnIn(10), Thin(3))
+	steps = 0
+	for range seq {
+		break
+	}
+	if steps != 13 {
+		t.Errorf("first sample took %d steps, want 13", steps)
+	}
+	// Ranging again continues the chain, without another burn-in.
+	steps = 0
+	for range seq {
+		break
+	}
+	if steps != 3 {
+		t.Errorf("sample after continuing took %d steps, want 3", steps)
+	}
+}
+
+func TestMCMCPanics(t *testing.T) {
+	ld := func(x []float64) float64 { return math.Log(max(x[0], 0)) }
+	for name, f := range map[string]func(){
+		"empty init":   func() { MCMC(ld, nil, 1) },
+		"zero step":    func() { MCMC(ld, []float64{1}, 0) },
+		"invalid init": func() { MCMC(ld, []float64{-1}, 1) },
+		"BurnIn(-1)":   func() { BurnIn(-1) },
+		"Thin(0)":      func() { Thin(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
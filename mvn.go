@@ -0,0 +1,123 @@
+package rnd
+
+import "math"
+
+// MVNormal is a multivariate normal distribution. It is safe for concurrent
+// use.
+type MVNormal struct {
+	mean []float64
+	// l is the lower-triangular Cholesky factor of the covariance matrix.
+	l [][]float64
+}
+
+// MultivariateNormal returns the multivariate normal distribution with the
+// given mean vector and covariance matrix. The covariance matrix is factored
+// once, so sampling a vector of dimension n only costs n normal variates and
+// O(n²) arithmetic. mean and cov are not retained.
+//
+// MultivariateNormal panics if cov is not a len(mean)×len(mean) symmetric,
+// positive semi-definite matrix.
+func MultivariateNormal(mean []float64, cov [][]float64) *MVNormal {
+	l, ok := cholesky(cov)
+	if !ok || len(l) != len(mean) {
+		panic("invalid covariance matrix for MultivariateNormal")
+	}
+	return &MVNormal{
+		mean: append([]float64(nil), mean...),
+		l:    l,
+	}
+}
+
+// Dim returns the dimension of d.
+func (d *MVNormal) Dim() int {
+	return len(d.mean)
+}
+
+// Next returns a pseudo-random vector drawn from d. It synchronizes only once
+// per vector.
+func (d *MVNormal) Next() []float64 {
+	z := make([]float64, len(d.mean))
+	FillNormFloat64(z)
+	d.transform(z)
+	return z
+}
+
+// Sample returns a vector drawn from d, using g as the source of randomness.
+func (d *MVNormal) Sample(g Generator) []float64 {
+	z := make([]float64, len(d.mean))
+	for i := range z {
+		z[i] = g.NormFloat64()
+	}
+	d.transform(z)
+	return z
+}
+
+// transform maps a vector of independent standard normal variates to one from
+// d, in place.
+func (d *MVNormal) transform(z []float64) {
+	// Row i of l only depends on z[:i+1], so going backwards works in place.
+	for i := len(z) - 1; i >= 0; i-- {
+		v := d.mean[i]
+		for j, c := range d.l[i][:i+1] {
+			v += c * z[j]
+		}
+		z[i] = v
+	}
+}
+
+// cholesky returns the lower-triangular matrix l with l·lᵀ = a. It reports
+// false, if a is not square, symmetric and positive semi-definite.
+// Semi-definite matrices, like those of perfectly correlated variables, yield
+// zero columns in l.
+func cholesky(a [][]float64) (l [][]float64, ok bool) {
+	n := len(a)
+	var scale float64
+	for i, row := range a {
+		if len(row) != n {
+			return nil, false
+		}
+		for j := range i {
+			if d := math.Abs(a[i][j] - a[j][i]); !(d <= 1e-9*(math.Abs(a[i][j])+math.Abs(a[j][i]))) {
+				return nil, false
+			}
+		}
+		scale = max(scale, math.Abs(row[i]))
+	}
+	eps := 1e-12 * scale
+	l = make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, i+1)
+	}
+	for j := range n {
+		d := a[j][j]
+		for k := range j {
+			d -= l[j][k] * l[j][k]
+		}
+		if !(d >= -eps) {
+			return nil, false
+		}
+		if d <= eps {
+			// The column is linearly dependent on the previous ones.
+			for i := j + 1; i < n; i++ {
+				v := a[i][j]
+				for k := range j {
+					v -= l[i][k] * l[j][k]
+				}
+				if math.Abs(v) > 1e-6*scale {
+					return nil, false
+				}
+			}
+			continue
+		}
+		ljj := math.Sqrt(d)
+		l[j][j] = ljj
+		for i := j + 1; i < n; i++ {
+			v := a[i][j]
+			for k := range j {
+				v -= l[i][k] * l[j][k]
+			}
+			l[i][j] = v / ljj
+		}
+	}
+	return l, true
+}
@@ -0,0 +1,76 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMultivariateNormal(t *testing.T) {
+	mean := []float64{1, -2, 3}
+	cov := [][]float64{
+		{4, 2, 0},
+		{2, 2, -1},
+		{0, -1, 1},
+	}
+	d := MultivariateNormal(mean, cov)
+	if d.Dim() != 3 {
+		t.Fatalf("Dim() = %d, want 3", d.Dim())
+	}
+
+	const n = 20000
+	var sum [3]float64
+	var prod [3][3]float64
+	for i := range n {
+		var v []float64
+		if i%2 == 0 {
+			v = d.Next()
+		} else {
+			v = d.Sample(Global())
+		}
+		for j := range v {
+			sum[j] += v[j]
+			for k := range v {
+				prod[j][k] += (v[j] - mean[j]) * (v[k] - mean[k])
+			}
+		}
+	}
+	for j := range mean {
+		if m := sum[j] / n; math.Abs(m-mean[j]) > 0.1 {
+			t.Errorf("mean[%d] = %v, want %v", j, m, mean[j])
+		}
+		for k := range mean {
+			if c := prod[j][k] / n; math.Abs(c-cov[j][k]) > 0.15 {
+				t.Errorf("cov[%d][%d] = %v, want %v", j, k, c, cov[j][k])
+			}
+		}
+	}
+}
+
+func TestMultivariateNormalSemiDefinite(t *testing.T) {
+	// Perfectly correlated.
+	d := MultivariateNormal([]float64{0, 0}, [][]float64{{1, 1}, {1, 1}})
+	for range 100 {
+		if v := d.Next(); math.Abs(v[0]-v[1]) > 1e-9 {
+			t.Fatalf("Next() = %v, want equal components", v)
+		}
+	}
+}
+
+func TestMultivariateNormalPanics(t *testing.T) {
+	for _, cov := range [][][]float64{
+		{{1, 0}, {0, 1}, {0, 0}},
+		{{1, 0.5}, {0, 1}},
+		{{1, 2}, {2, 1}},
+		{{-1, 0}, {0, 1}},
+		{{1}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("MultivariateNormal(_, %v) did not panic", cov)
+				}
+			}()
+			MultivariateNormal([]float64{0, 0}, cov)
+		}()
+	}
+}
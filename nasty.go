@@ -0,0 +1,102 @@
+package rnd
+
+import "strings"
+
+// NastyClass is a class of problematic input included by NastyString.
+type NastyClass uint8
+
+const (
+	// NastyInvalid includes invalid UTF-8: stray continuation bytes,
+	// truncated sequences and bytes which never occur in UTF-8.
+	NastyInvalid NastyClass = 1 << iota
+	// NastyOverlong includes overlong encodings, like 0xc0 0xaf for '/', and
+	// encoded surrogates. These are invalid UTF-8, but get accepted by sloppy
+	// decoders.
+	NastyOverlong
+	// NastyNUL includes NUL bytes.
+	NastyNUL
+	// NastyControl includes other control characters, like carriage
+	// returns, escape and U+2028 LINE SEPARATOR.
+	NastyControl
+	// NastyBOM includes byte order marks and the noncharacter U+FFFE.
+	NastyBOM
+	// NastyCombining includes runs of combining characters, some of them
+	// without a base character.
+	NastyCombining
+	// NastyBidi includes bidirectional formatting characters, like U+202E
+	// RIGHT-TO-LEFT OVERRIDE.
+	NastyBidi
+	// NastyZeroWidth includes zero-width characters, like U+200B ZERO WIDTH
+	// SPACE and U+200D ZERO WIDTH JOINER.
+	NastyZeroWidth
+
+	// NastyAll includes all classes.
+	NastyAll NastyClass = 1<<iota - 1
+)
+
+// nastyFragments are the fragments inserted for the classes with a fixed set
+// of them, indexed by the bit position of the class.
+var nastyFragments = [...][]string{
+	{"\x80", "\xbf", "\xfe", "\xff", "\xc3", "\xe2\x82", "\xf0\x9f\x98", "\xf5\x80\x80\x80", "\xc3\x28"},
+	{"\xc0\xaf", "\xc1\xbf", "\xe0\x80\xaf", "\xe0\x9f\xbf", "\xf0\x80\x80\xaf", "\xc0\x80", "\xed\xa0\x80", "\xed\xbf\xbf"},
+	{"\x00"},
+	{"\r", "\n", "\r\n", "\t", "\x1b", "\x7f", "\b", "\u0085", "\u2028", "\u2029"},
+	{"\ufeff", "\ufffe"},
+	nil,
+	{"\u200e", "\u200f", "\u202a", "\u202b", "\u202c", "\u202d", "\u202e", "\u2066", "\u2067", "\u2068", "\u2069", "\u061c"},
+	{"\u200b", "\u200c", "\u200d", "\u2060", "\u180e"},
+}
+
+// NastyString returns a string made of n fragments, meant for testing the
+// robustness of parsers and other code handling untrusted text. Every
+// fragment is, with equal probability, either a random valid rune or a
+// problematic sequence from one of classes. If no classes are given, all are
+// included.
+//
+// Unless classes includes NastyInvalid or NastyOverlong, the string is valid
+// UTF-8. For valid random text, use UTF8String.
+//
+// NastyString panics if n < 0.
+func NastyString(n int, classes ...NastyClass) string {
+	if n < 0 {
+		panic("invalid argument to NastyString")
+	}
+	var c NastyClass
+	for _, cl := range classes {
+		c |= cl
+	}
+	if c&NastyAll == 0 {
+		c = NastyAll
+	}
+	var enabled []int
+	for i := range nastyFragments {
+		if c&(1<<i) != 0 {
+			enabled = append(enabled, i)
+		}
+	}
+	var sb strings.Builder
+	for range n {
+		if Intn(2) == 0 {
+			sb.WriteRune(Rune())
+			continue
+		}
+		i := enabled[Intn(len(enabled))]
+		if NastyClass(1<<i) == NastyCombining {
+			writeCombining(&sb)
+			continue
+		}
+		sb.WriteString(nastyFragments[i][Intn(len(nastyFragments[i]))])
+	}
+	return sb.String()
+}
+
+// writeCombining writes a run of 1 to 16 combining diacritical marks, with a
+// base character in most cases.
+func writeCombining(sb *strings.Builder) {
+	if Intn(4) != 0 {
+		sb.WriteByte(byte('a' + Intn(26)))
+	}
+	for range 1 + Intn(16) {
+		sb.WriteRune(rune(0x300 + Intn(0x70)))
+	}
+}
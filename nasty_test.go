@@ -0,0 +1,34 @@
+package rnd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNastyString(t *testing.T) {
+	if s := NastyString(0); s != "" {
+		t.Errorf("NastyString(0) = %q, want empty", s)
+	}
+	if s := NastyString(200, NastyNUL); !strings.Contains(s, "\x00") || !utf8.ValidString(s) {
+		t.Errorf("NastyString(200, NastyNUL) = %q, want valid UTF-8 with NUL", s)
+	}
+	if s := NastyString(200, NastyInvalid|NastyOverlong); utf8.ValidString(s) {
+		t.Errorf("NastyString(200, NastyInvalid|NastyOverlong) = %q, which is valid UTF-8", s)
+	}
+	valid := NastyAll &^ (NastyInvalid | NastyOverlong)
+	for range 100 {
+		if s := NastyString(50, valid); !utf8.ValidString(s) {
+			t.Fatalf("NastyString(50, %#x) = %q, which is not valid UTF-8", valid, s)
+		}
+	}
+	if s := NastyString(200, NastyBidi); !strings.ContainsAny(s, "\u200e\u200f\u202a\u202b\u202c\u202d\u202e\u2066\u2067\u2068\u2069\u061c") {
+		t.Errorf("NastyString(200, NastyBidi) = %q, want bidi controls", s)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NastyString(-1) did not panic")
+		}
+	}()
+	NastyString(-1)
+}
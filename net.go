@@ -0,0 +1,196 @@
+package rnd
+
+import (
+	"net"
+	"net/netip"
+	"slices"
+)
+
+// AddrOption restricts the addresses returned by IPv4, IPv6 and AddrIn.
+type AddrOption uint8
+
+const (
+	// NoReserved excludes special-purpose addresses, as registered by IANA.
+	// This includes private, loopback, link-local and documentation
+	// addresses. For IPv6, only global unicast addresses (2000::/3) are
+	// returned.
+	NoReserved AddrOption = 1 << iota
+	// NoMulticast excludes multicast addresses.
+	NoMulticast
+	// NoNetworkBroadcast excludes the network and broadcast address of IPv4
+	// prefixes of length 30 or less, for AddrIn.
+	NoNetworkBroadcast
+)
+
+var (
+	reservedV4 = parsePrefixes(
+		"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8",
+		"169.254.0.0/16", "172.16.0.0/12", "192.0.0.0/24", "192.0.2.0/24",
+		"192.88.99.0/24", "192.168.0.0/16", "198.18.0.0/15",
+		"198.51.100.0/24", "203.0.113.0/24", "240.0.0.0/4",
+	)
+	multicastV4 = parsePrefixes("224.0.0.0/4")
+	// reservedV6 are the special-purpose prefixes inside of 2000::/3.
+	reservedV6  = parsePrefixes("2001::/23", "2001:db8::/32", "2002::/16", "3fff::/20")
+	multicastV6 = parsePrefixes("ff00::/8")
+)
+
+func parsePrefixes(s ...string) []netip.Prefix {
+	ps := make([]netip.Prefix, len(s))
+	for i, s := range s {
+		ps[i] = netip.MustParsePrefix(s)
+	}
+	return ps
+}
+
+func inAny(a netip.Addr, ps []netip.Prefix) bool {
+	for _, p := range ps {
+		if p.Contains(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func addrOptions(opts []AddrOption) AddrOption {
+	var o AddrOption
+	for _, opt := range opts {
+		o |= opt
+	}
+	return o
+}
+
+// IPv4 returns a uniformly chosen IPv4 address, excluding addresses according
+// to opts.
+func IPv4(opts ...AddrOption) netip.Addr {
+	o := addrOptions(opts)
+	for {
+		var b [4]byte
+		Read(b[:])
+		a := netip.AddrFrom4(b)
+		if o&NoReserved != 0 && inAny(a, reservedV4) {
+			continue
+		}
+		if o&NoMulticast != 0 && inAny(a, multicastV4) {
+			continue
+		}
+		return a
+	}
+}
+
+// IPv6 returns a uniformly chosen IPv6 address, excluding addresses according
+// to opts.
+func IPv6(opts ...AddrOption) netip.Addr {
+	o := addrOptions(opts)
+	for {
+		var b [16]byte
+		Read(b[:])
+		if o&NoReserved != 0 {
+			// Restrict to 2000::/3.
+			b[0] = b[0]&0x1f | 0x20
+		}
+		a := netip.AddrFrom16(b)
+		if o&NoReserved != 0 && inAny(a, reservedV6) {
+			continue
+		}
+		if o&NoMulticast != 0 && inAny(a, multicastV6) {
+			continue
+		}
+		return a
+	}
+}
+
+// AddrIn returns a uniformly chosen address in p. NoNetworkBroadcast is the
+// only option respected by AddrIn. It panics if p is not valid.
+func AddrIn(p netip.Prefix, opts ...AddrOption) netip.Addr {
+	if !p.IsValid() {
+		panic("invalid prefix for AddrIn")
+	}
+	o := addrOptions(opts)
+	p = p.Masked()
+	prefix := p.Addr().AsSlice()
+	hostBits := len(prefix)*8 - p.Bits()
+	excl := o&NoNetworkBroadcast != 0 && p.Addr().Is4() && hostBits >= 2
+	for {
+		b := make([]byte, len(prefix))
+		Read(b)
+		allZero, allOne := true, true
+		for i := range b {
+			// mask has the bits set, which belong to the host part.
+			var mask byte = 0xff
+			if n := p.Bits() - 8*i; n >= 8 {
+				mask = 0
+			} else if n > 0 {
+				mask >>= n
+			}
+			b[i] = prefix[i] | b[i]&mask
+			allZero = allZero && b[i]&mask == 0
+			allOne = allOne && b[i]&mask == mask
+		}
+		if excl && (allZero || allOne) {
+			continue
+		}
+		a, _ := netip.AddrFromSlice(b)
+		return a.WithZone(p.Addr().Zone())
+	}
+}
+
+// MACOption modifies the addresses returned by MAC.
+type MACOption uint8
+
+const (
+	// MACMulticast sets the group bit, returning multicast addresses.
+	MACMulticast MACOption = 1 << iota
+	// MACUniversal clears the locally administered bit. Note that universally
+	// administered addresses should contain an OUI assigned by the IEEE.
+	MACUniversal
+)
+
+// MAC returns a random MAC-48 address. By default, it is a locally
+// administered unicast address, as is appropriate for virtual interfaces.
+func MAC(opts ...MACOption) net.HardwareAddr {
+	var o MACOption
+	for _, opt := range opts {
+		o |= opt
+	}
+	a := make(net.HardwareAddr, 6)
+	Read(a)
+	a[0] = a[0]&^0x03 | 0x02
+	if o&MACMulticast != 0 {
+		a[0] |= 0x01
+	}
+	if o&MACUniversal != 0 {
+		a[0] &^= 0x02
+	}
+	return a
+}
+
+// Port returns a uniformly chosen port in [lo,hi], which is not in exclude.
+// It panics if there is no such port.
+func Port(lo, hi uint16, exclude ...uint16) uint16 {
+	if lo > hi {
+		panic("invalid range for Port")
+	}
+	var excl []uint16
+	for _, p := range exclude {
+		if p >= lo && p <= hi {
+			excl = append(excl, p)
+		}
+	}
+	slices.Sort(excl)
+	excl = slices.Compact(excl)
+	n := int(hi) - int(lo) + 1 - len(excl)
+	if n <= 0 {
+		panic("no port left to choose for Port")
+	}
+	// Choose the k-th allowed port, by skipping all excluded ports not
+	// greater than it.
+	p := int(lo) + Intn(n)
+	for _, e := range excl {
+		if int(e) > p {
+			break
+		}
+		p++
+	}
+	return uint16(p)
+}
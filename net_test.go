@@ -0,0 +1,96 @@
+package rnd
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestIPv4(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		if a := IPv4(); !a.Is4() {
+			t.Fatalf("IPv4() = %v, is not an IPv4 address", a)
+		}
+		a := IPv4(NoReserved, NoMulticast)
+		if !a.Is4() || !a.IsGlobalUnicast() || a.IsPrivate() || a.IsMulticast() || inAny(a, reservedV4) {
+			t.Fatalf("IPv4(NoReserved, NoMulticast) = %v", a)
+		}
+		if a := IPv4(NoMulticast); a.IsMulticast() {
+			t.Fatalf("IPv4(NoMulticast) = %v", a)
+		}
+	}
+}
+
+func TestIPv6(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		if a := IPv6(); !a.Is6() {
+			t.Fatalf("IPv6() = %v, is not an IPv6 address", a)
+		}
+		a := IPv6(NoReserved)
+		if !a.Is6() || !a.IsGlobalUnicast() || a.IsPrivate() || a.Is4In6() || inAny(a, reservedV6) {
+			t.Fatalf("IPv6(NoReserved) = %v", a)
+		}
+		if a := IPv6(NoMulticast); a.IsMulticast() {
+			t.Fatalf("IPv6(NoMulticast) = %v", a)
+		}
+	}
+}
+
+func TestAddrIn(t *testing.T) {
+	for _, s := range []string{"192.168.0.0/16", "10.1.2.3/30", "10.0.0.1/32", "0.0.0.0/0", "2001:db8::/32", "2001:db8::/125", "::/0"} {
+		p := netip.MustParsePrefix(s)
+		for i := 0; i < 100; i++ {
+			if a := AddrIn(p); !p.Contains(a) {
+				t.Fatalf("AddrIn(%v) = %v", p, a)
+			}
+		}
+	}
+	p := netip.MustParsePrefix("10.0.0.0/30")
+	for i := 0; i < 100; i++ {
+		a := AddrIn(p, NoNetworkBroadcast)
+		if !p.Contains(a) || a == p.Addr() || a == netip.MustParseAddr("10.0.0.3") {
+			t.Fatalf("AddrIn(%v, NoNetworkBroadcast) = %v", p, a)
+		}
+	}
+}
+
+func TestMAC(t *testing.T) {
+	tcs := []struct {
+		opts []MACOption
+		bits byte
+	}{
+		{nil, 0x02},
+		{[]MACOption{MACMulticast}, 0x03},
+		{[]MACOption{MACUniversal}, 0x00},
+		{[]MACOption{MACUniversal, MACMulticast}, 0x01},
+	}
+	for _, tc := range tcs {
+		for i := 0; i < 10; i++ {
+			a := MAC(tc.opts...)
+			if len(a) != 6 || a[0]&0x03 != tc.bits {
+				t.Fatalf("MAC(%v) = %v, want low bits %02b", tc.opts, a, tc.bits)
+			}
+		}
+	}
+}
+
+func TestPort(t *testing.T) {
+	seen := make(map[uint16]int)
+	for i := 0; i < 1000; i++ {
+		seen[Port(10, 15, 12, 10, 12, 100)]++
+	}
+	if len(seen) != 4 || seen[10] != 0 || seen[12] != 0 {
+		t.Errorf("Port(10, 15, 12, 10, 12, 100) returned %v", seen)
+	}
+	if p := Port(65535, 65535); p != 65535 {
+		t.Errorf("Port(65535, 65535) = %d", p)
+	}
+	if p := Port(0, 65535, 0); p == 0 {
+		t.Errorf("Port(0, 65535, 0) = 0")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Port(1, 2, 1, 2) did not panic")
+		}
+	}()
+	Port(1, 2, 1, 2)
+}
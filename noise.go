@@ -0,0 +1,47 @@
+package rnd
+
+// AddNoise adds independent normally distributed noise with mean 0 and
+// standard deviation stddev to every element of dst. It synchronizes only
+// once, like FillNormFloat64. It panics if stddev is negative or NaN.
+func AddNoise(dst []float64, stddev float64) {
+	if !(stddev >= 0) {
+		panic("invalid standard deviation for AddNoise")
+	}
+	defer reseed(len(dst))
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	for i := range dst {
+		dst[i] += stddev * zigNorm(src.src)
+	}
+}
+
+// AddRelativeNoise multiplies every element of dst by 1+rel·z, with z an
+// independent standard normal value, so the noise is proportional to the
+// magnitude of the element. Zero elements stay zero. AddRelativeNoise
+// synchronizes only once. It panics if rel is negative or NaN.
+func AddRelativeNoise(dst []float64, rel float64) {
+	if !(rel >= 0) {
+		panic("invalid relative deviation for AddRelativeNoise")
+	}
+	defer reseed(len(dst))
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	for i := range dst {
+		dst[i] *= 1 + rel*zigNorm(src.src)
+	}
+}
+
+// AddUniformNoise adds independent noise, uniformly distributed in
+// [-width,width), to every element of dst. It synchronizes only once. It
+// panics if width is negative or NaN.
+func AddUniformNoise(dst []float64, width float64) {
+	if !(width >= 0) {
+		panic("invalid width for AddUniformNoise")
+	}
+	defer reseed(len(dst))
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	for i := range dst {
+		dst[i] += width * (2*uniform(src.src) - 1)
+	}
+}
@@ -0,0 +1,62 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+// meanStd returns the mean and standard deviation of s.
+func meanStd(s []float64) (mean, std float64) {
+	for _, v := range s {
+		mean += v
+	}
+	mean /= float64(len(s))
+	for _, v := range s {
+		std += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(std / float64(len(s)))
+}
+
+func TestAddNoise(t *testing.T) {
+	const n = 100000
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = 10
+	}
+	AddNoise(s, 2)
+	if m, sd := meanStd(s); math.Abs(m-10) > 0.05 || math.Abs(sd-2) > 0.05 {
+		t.Errorf("AddNoise(10, 2) has mean %v and standard deviation %v, want 10 and 2", m, sd)
+	}
+
+	for i := range s {
+		s[i] = 100
+	}
+	AddRelativeNoise(s, 0.1)
+	if m, sd := meanStd(s); math.Abs(m-100) > 0.5 || math.Abs(sd-10) > 0.2 {
+		t.Errorf("AddRelativeNoise(100, 0.1) has mean %v and standard deviation %v, want 100 and 10", m, sd)
+	}
+	z := []float64{0, 0}
+	if AddRelativeNoise(z, 1); z[0] != 0 || z[1] != 0 {
+		t.Errorf("AddRelativeNoise changed zero elements to %v", z)
+	}
+
+	for i := range s {
+		s[i] = 5
+	}
+	AddUniformNoise(s, 1)
+	for _, v := range s {
+		if v < 4 || v >= 6 {
+			t.Fatalf("AddUniformNoise(5, 1) returned %v, want in [4,6)", v)
+		}
+	}
+	if m, sd := meanStd(s); math.Abs(m-5) > 0.01 || math.Abs(sd-1/math.Sqrt(3)) > 0.01 {
+		t.Errorf("AddUniformNoise(5, 1) has mean %v and standard deviation %v, want 5 and %v", m, sd, 1/math.Sqrt(3))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddNoise(s, -1) did not panic")
+		}
+	}()
+	AddNoise(s, -1)
+}
@@ -0,0 +1,125 @@
+package rnd
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Option configures a generator returned by New or Derive.
+type Option func(*options)
+
+type options struct {
+	backend Backend
+	batch   int
+	reseed  ReseedPolicy
+}
+
+// WithBackend makes the generator use b, instead of the backend of the global
+// source. It panics if b is not a valid Backend.
+func WithBackend(b Backend) Option {
+	if b > Xoshiro256 {
+		panic("invalid argument to WithBackend")
+	}
+	return func(o *options) { o.backend = b }
+}
+
+// WithBufferedBatch makes the generator draw n values at a time from its
+// backend and serve them from a buffer. This amortizes the per-call overhead
+// of the backend for code doing many draws in a row. It panics if n < 1.
+func WithBufferedBatch(n int) Option {
+	if n < 1 {
+		panic("invalid argument to WithBufferedBatch")
+	}
+	return func(o *options) { o.batch = n }
+}
+
+// ReseedPolicy determines when a generator re-seeds itself. A generator is
+// re-seeded with a new random seed, once either limit is reached. Zero fields
+// are ignored, so the zero ReseedPolicy never re-seeds.
+type ReseedPolicy struct {
+	// After is the number of 64-bit values to generate before re-seeding.
+	After uint64
+	// Interval is the time after which to re-seed. It is checked every few
+	// hundred values, so it is only approximately honored.
+	Interval time.Duration
+}
+
+// WithReseedPolicy makes the generator re-seed itself according to p, like
+// the global source does. Re-seeding limits the damage if the state of the
+// generator leaks. Re-seeding a generator returned by Derive makes its
+// stream irreproducible from that point on.
+func WithReseedPolicy(p ReseedPolicy) Option {
+	return func(o *options) { o.reseed = p }
+}
+
+// newRandOpts returns a new Rand seeded with seed, configured by opts.
+func newRandOpts(seed [32]byte, opts []Option) *Rand {
+	o := options{backend: defaultBackend}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return newRandOptions(seed, &o)
+}
+
+// newRandOptions returns a new Rand seeded with seed, configured by o. The
+// Rand retains o, so Split can configure children the same way.
+func newRandOptions(seed [32]byte, o *options) *Rand {
+	s := newSource(o.backend, seed)
+	if o.reseed != (ReseedPolicy{}) {
+		s = &reseedSource{
+			src:     s,
+			backend: o.backend,
+			policy:  o.reseed,
+			last:    time.Now(),
+		}
+	}
+	if o.batch > 1 {
+		s = &batchSource{src: s, buf: make([]uint64, o.batch), pos: o.batch}
+	}
+	r := FromSource(s)
+	r.opts = o
+	return r
+}
+
+// reseedSource wraps a source, re-seeding it according to a policy.
+type reseedSource struct {
+	src     rand.Source
+	backend Backend
+	policy  ReseedPolicy
+	n       uint64
+	last    time.Time
+}
+
+// reseedCheckTime is the number of values after which reseedSource checks
+// the time.
+const reseedCheckTime = 256
+
+func (s *reseedSource) Uint64() uint64 {
+	s.n++
+	if (s.policy.After > 0 && s.n > s.policy.After) ||
+		(s.policy.Interval > 0 && s.n%reseedCheckTime == 0 && time.Since(s.last) >= s.policy.Interval) {
+		s.src = newSource(s.backend, newSeed())
+		s.n, s.last = 1, time.Now()
+	}
+	return s.src.Uint64()
+}
+
+// batchSource wraps a source, drawing from it in batches.
+type batchSource struct {
+	src rand.Source
+	buf []uint64
+	// pos is the index of the next unused value in buf.
+	pos int
+}
+
+func (s *batchSource) Uint64() uint64 {
+	if s.pos == len(s.buf) {
+		for i := range s.buf {
+			s.buf[i] = s.src.Uint64()
+		}
+		s.pos = 0
+	}
+	v := s.buf[s.pos]
+	s.pos++
+	return v
+}
@@ -0,0 +1,60 @@
+package rnd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	// Derived streams must be the same for the same options, independent of
+	// batching.
+	for _, b := range []Backend{ChaCha8, PCG, Xoshiro256} {
+		r1 := Derive("options", WithBackend(b))
+		r2 := Derive("options", WithBackend(b), WithBufferedBatch(7))
+		for range 20 {
+			if x, y := r1.Uint64(), r2.Uint64(); x != y {
+				t.Fatalf("WithBufferedBatch changed stream of %v: %#x != %#x", b, x, y)
+			}
+		}
+	}
+	if Derive("options", WithBackend(PCG)).Uint64() == Derive("options", WithBackend(ChaCha8)).Uint64() {
+		t.Error("different backends produce the same stream")
+	}
+
+	// A re-seeding generator diverges from its unseeded counterpart.
+	r1 := Derive("reseed")
+	r2 := Derive("reseed", WithReseedPolicy(ReseedPolicy{After: 10}))
+	for i := range 20 {
+		x, y := r1.Uint64(), r2.Uint64()
+		if i < 10 && x != y {
+			t.Fatalf("value %d differs before re-seeding", i)
+		}
+		if i >= 10 && x == y {
+			t.Fatalf("value %d is the same after re-seeding", i)
+		}
+	}
+	r := New(WithReseedPolicy(ReseedPolicy{Interval: time.Nanosecond}))
+	for range 2 * reseedCheckTime {
+		r.Uint64()
+	}
+	s := r.src.(*reseedSource)
+	if s.n > reseedCheckTime {
+		t.Errorf("generator was not re-seeded after Interval")
+	}
+}
+
+func TestOptionsPanic(t *testing.T) {
+	for name, f := range map[string]func(){
+		"WithBackend(42)":      func() { WithBackend(42) },
+		"WithBufferedBatch(0)": func() { WithBufferedBatch(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,96 @@
+package rnd
+
+import "strings"
+
+// Character classes used by Password.
+const (
+	lowerChars  = "abcdefghijklmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars  = "0123456789"
+	symbolChars = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+	// ambiguousChars are excluded by PasswordOpts.NoAmbiguous.
+	ambiguousChars = "0O1lI|`'\""
+)
+
+// PasswordOpts configures Password.
+type PasswordOpts struct {
+	// Length is the number of characters of the password.
+	Length int
+	// Lower, Upper, Digits and Symbols enable the respective classes of ASCII
+	// characters. Every enabled class occurs at least once in the password.
+	Lower, Upper, Digits, Symbols bool
+	// NoAmbiguous excludes characters which are easily confused, like 0 and
+	// O or 1, l and I.
+	NoAmbiguous bool
+	// Secure causes Password to use crypto/rand instead of the package source.
+	Secure bool
+}
+
+// Password returns a random password, according to opts. Every password
+// satisfying opts is chosen with the same probability.
+//
+// Unless opts.Secure is set, the password is generated using the package
+// source, which is not cryptographically secure. It is then meant for test
+// accounts and must not be used to protect anything.
+//
+// Password panics, if no character class is enabled or opts.Length is smaller
+// than the number of enabled classes.
+func Password(opts PasswordOpts) string {
+	var classes []string
+	for _, c := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{opts.Lower, lowerChars},
+		{opts.Upper, upperChars},
+		{opts.Digits, digitChars},
+		{opts.Symbols, symbolChars},
+	} {
+		if !c.enabled {
+			continue
+		}
+		if opts.NoAmbiguous {
+			c.chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(ambiguousChars, r) {
+					return -1
+				}
+				return r
+			}, c.chars)
+		}
+		classes = append(classes, c.chars)
+	}
+	if len(classes) == 0 || opts.Length < len(classes) {
+		panic("invalid PasswordOpts")
+	}
+	chars := strings.Join(classes, "")
+
+	var intn func(int) int
+	if opts.Secure {
+		intn = secure.IntN
+	} else {
+		intn = Intn
+	}
+
+	// To choose uniformly among the passwords containing all classes, we
+	// generate passwords uniformly over all characters and reject the ones
+	// missing a class.
+	b := make([]byte, opts.Length)
+	for {
+		for i := range b {
+			b[i] = chars[intn(len(chars))]
+		}
+		if containsAll(b, classes) {
+			return string(b)
+		}
+	}
+}
+
+// containsAll returns whether b contains a character from every class.
+func containsAll(b []byte, classes []string) bool {
+	for _, c := range classes {
+		if !strings.ContainsAny(string(b), c) {
+			return false
+		}
+	}
+	return true
+}
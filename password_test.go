@@ -0,0 +1,42 @@
+package rnd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassword(t *testing.T) {
+	tcs := []PasswordOpts{
+		{Length: 1, Lower: true},
+		{Length: 4, Lower: true, Upper: true, Digits: true, Symbols: true},
+		{Length: 16, Lower: true, Digits: true, NoAmbiguous: true},
+		{Length: 32, Upper: true, Symbols: true, Secure: true},
+	}
+	for _, opts := range tcs {
+		p := Password(opts)
+		if len(p) != opts.Length {
+			t.Errorf("Password(%+v) = %q, want length %d", opts, p, opts.Length)
+		}
+		for _, c := range []struct {
+			enabled bool
+			chars   string
+		}{{opts.Lower, lowerChars}, {opts.Upper, upperChars}, {opts.Digits, digitChars}, {opts.Symbols, symbolChars}} {
+			if strings.ContainsAny(p, c.chars) != c.enabled {
+				t.Errorf("Password(%+v) = %q, contains %q: %v", opts, p, c.chars, !c.enabled)
+			}
+		}
+		if opts.NoAmbiguous && strings.ContainsAny(p, ambiguousChars) {
+			t.Errorf("Password(%+v) = %q, contains ambiguous characters", opts, p)
+		}
+	}
+	for _, opts := range []PasswordOpts{{Length: 10}, {Length: 1, Lower: true, Upper: true}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Password(%+v) did not panic", opts)
+				}
+			}()
+			Password(opts)
+		}()
+	}
+}
@@ -0,0 +1,62 @@
+package rnd
+
+import (
+	"iter"
+	"math"
+	"time"
+)
+
+// seconds converts s seconds to a time.Duration, saturating at the maximum
+// duration.
+func seconds(s float64) time.Duration {
+	if d := s * float64(time.Second); d < math.MaxInt64 {
+		return time.Duration(d)
+	}
+	return math.MaxInt64
+}
+
+// PoissonProcess returns an infinite iterator over the gaps between
+// successive events of a Poisson process with rate events per second. The
+// gaps are exponentially distributed with mean 1/rate seconds. It panics if
+// rate <= 0.
+func PoissonProcess(rate float64) iter.Seq[time.Duration] {
+	if !(rate > 0) {
+		panic("invalid rate for PoissonProcess")
+	}
+	return seq(func() time.Duration {
+		return seconds(ExpFloat64() / rate)
+	})
+}
+
+// PoissonProcessFunc returns an infinite iterator over the gaps between
+// successive events of a non-homogeneous Poisson process. rate(t) is the
+// rate in events per second at time t, measured from the start of the
+// process. It must be in [0,maxRate] for all t.
+//
+// Events are generated by thinning a homogeneous process with rate maxRate,
+// so maxRate should be a tight bound. PoissonProcessFunc panics if maxRate
+// <= 0. The iterator panics if rate returns a value outside [0,maxRate].
+func PoissonProcessFunc(rate func(t time.Duration) float64, maxRate float64) iter.Seq[time.Duration] {
+	if !(maxRate > 0) {
+		panic("invalid maxRate for PoissonProcessFunc")
+	}
+	return func(yield func(time.Duration) bool) {
+		// Keep track of time in seconds, to avoid accumulating rounding
+		// errors.
+		var t, last float64
+		for {
+			t += ExpFloat64() / maxRate
+			r := rate(seconds(t))
+			if !(r >= 0 && r <= maxRate) {
+				panic("invalid rate in PoissonProcessFunc")
+			}
+			if Float64()*maxRate >= r {
+				continue
+			}
+			if !yield(seconds(t - last)) {
+				return
+			}
+			last = t
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPoissonProcess(t *testing.T) {
+	const n = 10000
+	var sum time.Duration
+	i := 0
+	for d := range PoissonProcess(100) {
+		if d < 0 {
+			t.Fatalf("PoissonProcess yielded negative gap %v", d)
+		}
+		sum += d
+		if i++; i == n {
+			break
+		}
+	}
+	// The mean gap is 10ms, with a standard deviation of the mean of 0.1ms.
+	if mean := sum / n; mean < 9500*time.Microsecond || mean > 10500*time.Microsecond {
+		t.Errorf("mean gap of PoissonProcess(100) = %v, want 10ms", mean)
+	}
+}
+
+func TestPoissonProcessFunc(t *testing.T) {
+	// The rate is 200/s during the first second and 20/s afterwards.
+	rate := func(t time.Duration) float64 {
+		if t < time.Second {
+			return 200
+		}
+		return 20
+	}
+	var at time.Duration
+	var counts [2]int
+	for d := range PoissonProcessFunc(rate, 250) {
+		if at += d; at >= 2*time.Second {
+			break
+		}
+		counts[at/time.Second]++
+	}
+	if math.Abs(float64(counts[0])-200) > 60 || math.Abs(float64(counts[1])-20) > 20 {
+		t.Errorf("PoissonProcessFunc generated %v events per second, want about [200 20]", counts)
+	}
+}
@@ -0,0 +1,197 @@
+// Package quasi provides quasi-random (low-discrepancy) sequences.
+//
+// Quasi-random sequences cover the unit hypercube more evenly than
+// pseudo-random numbers, which makes Monte-Carlo integration converge faster.
+// The generators in this package are randomly scrambled, using the global
+// source of package gonih.org/rnd, so independent generators produce
+// different sequences, while keeping their low discrepancy.
+//
+// Like a *rnd.Rand, the generators in this package are not safe for
+// concurrent use.
+package quasi
+
+import (
+	"math"
+	"math/bits"
+
+	"gonih.org/rnd"
+)
+
+// MaxSobolDims is the maximum number of dimensions supported by NewSobol.
+const MaxSobolDims = 16
+
+// sobolParams contains the degree s, the coefficients a and the initial
+// direction numbers m of the primitive polynomials for dimensions 2 and up.
+// The values are taken from the table new-joe-kuo-6.21201, see Joe and Kuo,
+// "Constructing Sobol sequences with better two-dimensional projections",
+// 2008.
+var sobolParams = [MaxSobolDims - 1]struct {
+	s, a uint
+	m    []uint64
+}{
+	{1, 0, []uint64{1}},
+	{2, 1, []uint64{1, 3}},
+	{3, 1, []uint64{1, 3, 1}},
+	{3, 2, []uint64{1, 1, 1}},
+	{4, 1, []uint64{1, 1, 3, 3}},
+	{4, 4, []uint64{1, 3, 5, 13}},
+	{5, 2, []uint64{1, 1, 5, 5, 17}},
+	{5, 4, []uint64{1, 1, 5, 5, 5}},
+	{5, 7, []uint64{1, 1, 7, 11, 19}},
+	{5, 11, []uint64{1, 1, 5, 1, 1}},
+	{5, 13, []uint64{1, 1, 1, 3, 11}},
+	{5, 14, []uint64{1, 3, 5, 5, 31}},
+	{6, 1, []uint64{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint64{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint64{1, 3, 1, 13, 27, 49}},
+}
+
+// Sobol generates a Sobol sequence, scrambled by a random digital shift.
+type Sobol struct {
+	v     [][64]uint64
+	x     []uint64
+	shift []uint64
+	n     uint64
+}
+
+// NewSobol returns a new generator of the dims-dimensional Sobol sequence.
+// It panics if dims < 1 or dims > MaxSobolDims.
+func NewSobol(dims int) *Sobol {
+	if dims < 1 || dims > MaxSobolDims {
+		panic("invalid dimension for NewSobol")
+	}
+	s := &Sobol{
+		v:     make([][64]uint64, dims),
+		x:     make([]uint64, dims),
+		shift: make([]uint64, dims),
+	}
+	for i := range 64 {
+		s.v[0][i] = 1 << (63 - i)
+	}
+	for d := 1; d < dims; d++ {
+		p := sobolParams[d-1]
+		v := &s.v[d]
+		for i, m := range p.m {
+			v[i] = m << (63 - i)
+		}
+		for i := int(p.s); i < 64; i++ {
+			v[i] = v[i-int(p.s)] ^ (v[i-int(p.s)] >> p.s)
+			for k := uint(1); k < p.s; k++ {
+				v[i] ^= uint64((p.a>>(p.s-1-k))&1) * v[i-int(k)]
+			}
+		}
+	}
+	for d := range s.shift {
+		s.shift[d] = rnd.Uint64()
+		s.x[d] = s.shift[d]
+	}
+	return s
+}
+
+// Next returns the next point of the sequence, in [0,1)^dims.
+func (s *Sobol) Next() []float64 {
+	p := make([]float64, len(s.x))
+	for d, x := range s.x {
+		p[d] = float64(x>>11) / (1 << 53)
+	}
+	// Gray code construction: the next point differs from the current one by
+	// the direction number of the lowest zero bit of the index.
+	c := bits.TrailingZeros64(^s.n)
+	s.n++
+	for d := range s.x {
+		s.x[d] ^= s.v[d][c]
+	}
+	return p
+}
+
+// Halton generates a Halton sequence, scrambled by random digit
+// permutations.
+type Halton struct {
+	bases  []uint64
+	digits []int
+	perms  [][]uint64
+	n      uint64
+}
+
+// NewHalton returns a new generator of the dims-dimensional Halton sequence,
+// using the first dims primes as bases. It panics if dims < 1.
+//
+// The quality of the Halton sequence degrades for large dimensions. It is
+// best used for dims up to about 10.
+func NewHalton(dims int) *Halton {
+	if dims < 1 {
+		panic("invalid dimension for NewHalton")
+	}
+	h := &Halton{
+		bases:  primes(dims),
+		digits: make([]int, dims),
+		perms:  make([][]uint64, dims),
+	}
+	for d, b := range h.bases {
+		// The number of digits needed to fill the mantissa of a float64.
+		h.digits[d] = int(math.Ceil(53 / math.Log2(float64(b))))
+		h.perms[d] = make([]uint64, b)
+		for i, v := range rnd.Perm(int(b)) {
+			h.perms[d][i] = uint64(v)
+		}
+	}
+	return h
+}
+
+// Next returns the next point of the sequence, in [0,1)^dims.
+func (h *Halton) Next() []float64 {
+	p := make([]float64, len(h.bases))
+	for d, b := range h.bases {
+		var v float64
+		f := 1 / float64(b)
+		for i, n := 0, h.n; i < h.digits[d]; i++ {
+			v += float64(h.perms[d][n%b]) * f
+			n /= b
+			f /= float64(b)
+		}
+		p[d] = min(v, math.Nextafter(1, 0))
+	}
+	h.n++
+	return p
+}
+
+// primes returns the first n primes.
+func primes(n int) []uint64 {
+	ps := make([]uint64, 0, n)
+	for c := uint64(2); len(ps) < n; c++ {
+		prime := true
+		for _, p := range ps {
+			if p*p > c {
+				break
+			}
+			if c%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			ps = append(ps, c)
+		}
+	}
+	return ps
+}
+
+// LatinHypercube returns a Latin hypercube design of n points in
+// [0,1)^dims. For each dimension, every one of the n intervals [i/n,(i+1)/n)
+// contains exactly one point. Points are placed uniformly within their
+// intervals. It panics if n < 0 or dims < 1.
+func LatinHypercube(n, dims int) [][]float64 {
+	if n < 0 || dims < 1 {
+		panic("invalid argument to LatinHypercube")
+	}
+	pts := make([][]float64, n)
+	for i := range pts {
+		pts[i] = make([]float64, dims)
+	}
+	for d := range dims {
+		for i, j := range rnd.Perm(n) {
+			pts[i][d] = min((float64(j)+rnd.Float64())/float64(n), math.Nextafter(1, 0))
+		}
+	}
+	return pts
+}
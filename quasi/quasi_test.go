@@ -0,0 +1,92 @@
+package quasi
+
+import (
+	"slices"
+	"testing"
+)
+
+type generator interface {
+	Next() []float64
+}
+
+// checkStrata checks that the first n points of g are in [0,1)^dims and
+// that each of the strata[d] strata of dimension d contains the same number
+// of them. Points can lie very close to the boundary of a stratum, so counts
+// are allowed to be off by one, due to rounding.
+func checkStrata(t *testing.T, name string, g generator, n int, strata []int) {
+	t.Helper()
+	counts := make([][]int, len(strata))
+	for d := range counts {
+		counts[d] = make([]int, strata[d])
+	}
+	for i := 0; i < n; i++ {
+		p := g.Next()
+		if len(p) != len(strata) {
+			t.Fatalf("%s: len(Next()) = %d, want %d", name, len(p), len(strata))
+		}
+		for d, v := range p {
+			if v < 0 || v >= 1 {
+				t.Fatalf("%s: point %d = %v, want in [0,1)", name, i, p)
+			}
+			counts[d][int(v*float64(strata[d]))]++
+		}
+	}
+	for d, c := range counts {
+		for _, k := range c {
+			if want := n / strata[d]; k < want-1 || k > want+1 {
+				t.Errorf("%s: strata of dimension %d have counts %v, want %d each", name, d, c, want)
+				break
+			}
+		}
+	}
+}
+
+func TestSobol(t *testing.T) {
+	for _, dims := range []int{1, 2, MaxSobolDims} {
+		strata := make([]int, dims)
+		for d := range strata {
+			strata[d] = 16
+		}
+		checkStrata(t, "Sobol", NewSobol(dims), 1024, strata)
+	}
+	a, b := NewSobol(2), NewSobol(2)
+	if slices.Equal(a.Next(), b.Next()) {
+		t.Error("independent Sobol generators returned the same point")
+	}
+}
+
+func TestHalton(t *testing.T) {
+	checkStrata(t, "Halton", NewHalton(3), 900, []int{4, 9, 25})
+	a, b := NewHalton(5), NewHalton(5)
+	if slices.Equal(a.Next(), b.Next()) {
+		t.Error("independent Halton generators returned the same point")
+	}
+}
+
+func TestPrimes(t *testing.T) {
+	if got, want := primes(8), []uint64{2, 3, 5, 7, 11, 13, 17, 19}; !slices.Equal(got, want) {
+		t.Errorf("primes(8) = %v, want %v", got, want)
+	}
+}
+
+func TestLatinHypercube(t *testing.T) {
+	const n, dims = 50, 3
+	pts := LatinHypercube(n, dims)
+	if len(pts) != n {
+		t.Fatalf("len(LatinHypercube(%d, %d)) = %d", n, dims, len(pts))
+	}
+	for d := range dims {
+		seen := make([]bool, n)
+		for _, p := range pts {
+			if len(p) != dims || p[d] < 0 || p[d] >= 1 {
+				t.Fatalf("invalid point %v", p)
+			}
+			seen[int(p[d]*n)] = true
+		}
+		for i, ok := range seen {
+			if !ok {
+				t.Errorf("dimension %d has no point in stratum %d", d, i)
+			}
+		}
+	}
+}
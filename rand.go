@@ -0,0 +1,196 @@
+package rnd
+
+import (
+	"crypto/sha256"
+	"math/rand/v2"
+)
+
+// Rand is a source of pseudo-random numbers, independent of the global source
+// used by the package-level functions.
+//
+// Unlike the package-level functions, a Rand is not safe for concurrent use.
+type Rand struct {
+	src rand.Source
+	r   *rand.Rand
+	// opts is the configuration of r, if it was created by New or a
+	// similar function. It is nil for generators created by FromSource.
+	opts *options
+
+	// readVal contains the remainder of the 64-bit integer used for the most
+	// recent Read call, readPos the number of valid low-order bytes of it.
+	readVal uint64
+	readPos int8
+}
+
+// New returns a new, randomly seeded Rand, configured by opts.
+func New(opts ...Option) *Rand {
+	return newRandOpts(newSeed(), opts)
+}
+
+// newRand returns a new Rand seeded with seed.
+func newRand(seed [32]byte) *Rand {
+	return FromSource(newSource(defaultBackend, seed))
+}
+
+// FromSource returns a new Rand, which uses random values from src to
+// generate other random values. It does not re-seed src.
+//
+// FromSource is meant for injecting deterministic generators into code under
+// test. See package gonih.org/rnd/rndtest.
+func FromSource(src rand.Source) *Rand {
+	return &Rand{src: src, r: rand.New(src)}
+}
+
+// deriveSeed is mixed into the labels passed to Derive, so derived streams
+// differ between processes.
+var deriveSeed = newSeed()
+
+// Derive returns a new Rand, whose stream is derived from label.
+//
+// Within a process, calling Derive with the same label returns generators
+// producing the same stream, while different labels produce statistically
+// independent streams. The label is mixed with a per-process seed, so the
+// streams differ between processes. The generator is configured by opts, and
+// generators with different backends produce different streams.
+func Derive(label string, opts ...Option) *Rand {
+	h := sha256.New()
+	h.Write(deriveSeed[:])
+	h.Write([]byte(label))
+	var seed [32]byte
+	h.Sum(seed[:0])
+	return newRandOpts(seed, opts)
+}
+
+// Split returns a new Rand, whose stream is independent of r. It advances the
+// stream of r.
+//
+// Split is meant to hand out generators to parallel workers. The child is
+// seeded with 256 bits drawn from r and uses the same options as r, including
+// its backend and re-seeding policy. With the ChaCha8 backend, streams with
+// different keys are computationally independent and do not overlap in any
+// way that can be detected. With the other backends, overlap is vanishingly
+// unlikely, given the size of their state.
+//
+// Generators returned by FromSource or NewDRBG have no options, so their
+// children use the backend of the global source, without any options.
+func (r *Rand) Split() *Rand {
+	var seed [32]byte
+	read(seed[:], r.src)
+	if r.opts == nil {
+		return newRand(seed)
+	}
+	return newRandOptions(seed, r.opts)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func (r *Rand) Int63() int64 {
+	return r.r.Int64()
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+func (r *Rand) Uint32() uint32 {
+	return r.r.Uint32()
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+func (r *Rand) Uint64() uint64 {
+	return r.r.Uint64()
+}
+
+// Uint128 returns a pseudo-random 128-bit value, as its high and low 64 bits.
+func (r *Rand) Uint128() (hi, lo uint64) {
+	return r.src.Uint64(), r.src.Uint64()
+}
+
+// Int31 returns a non-negative pseudo-random 31-bit integer as an int32.
+func (r *Rand) Int31() int32 {
+	return r.r.Int32()
+}
+
+// Int returns a non-negative pseudo-random int.
+func (r *Rand) Int() int {
+	return r.r.Int()
+}
+
+// Int63n returns, as an int64, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (r *Rand) Int63n(n int64) int64 {
+	return r.r.Int64N(n)
+}
+
+// Uint64n returns, as a uint64, a pseudo-random number in [0,n).
+// It panics if n == 0.
+func (r *Rand) Uint64n(n uint64) uint64 {
+	return r.r.Uint64N(n)
+}
+
+// Int31n returns, as an int32, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (r *Rand) Int31n(n int32) int32 {
+	return r.r.Int32N(n)
+}
+
+// Intn returns, as an int, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	return r.r.IntN(n)
+}
+
+// Float64 returns, as a float64, a pseudo-random number in [0.0,1.0).
+func (r *Rand) Float64() float64 {
+	return r.r.Float64()
+}
+
+// Float32 returns, as a float32, a pseudo-random number in [0.0,1.0).
+func (r *Rand) Float32() float32 {
+	return r.r.Float32()
+}
+
+// Perm returns, as a slice of n ints, a pseudo-random permutation of the integers [0,n).
+func (r *Rand) Perm(n int) []int {
+	return r.r.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of elements. n is the number of
+// elements. Shuffle panics if n < 0. swap swaps the elements with indexes i
+// and j.
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	r.r.Shuffle(n, swap)
+}
+
+// Read generates len(p) random bytes and writes them into p. It always returns
+// len(p) and a nil error.
+func (r *Rand) Read(p []byte) (n int, err error) {
+	pos, val := r.readPos, r.readVal
+	for n = 0; n < len(p); n++ {
+		if pos == 0 {
+			val = r.src.Uint64()
+			pos = 8
+		}
+		p[n] = byte(val)
+		val >>= 8
+		pos--
+	}
+	r.readPos, r.readVal = pos, val
+	return n, nil
+}
+
+// NormFloat64 returns a normally distributed float64 in the range
+// [-math.MaxFloat64, +math.MaxFloat64] with
+// standard normal distribution (mean = 0, stddev = 1).
+func (r *Rand) NormFloat64() float64 {
+	return zigNorm(r.src)
+}
+
+// NormPair returns two independent, normally distributed float64s with
+// standard normal distribution (mean = 0, stddev = 1).
+func (r *Rand) NormPair() (float64, float64) {
+	return boxMuller(r.Uint128())
+}
+
+// ExpFloat64 returns an exponentially distributed float64 in the range
+// (0, +math.MaxFloat64] with an exponential distribution whose rate parameter
+// (lambda) is 1 and whose mean is 1/lambda (1).
+func (r *Rand) ExpFloat64() float64 {
+	return zigExp(r.src)
+}
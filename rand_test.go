@@ -0,0 +1,89 @@
+package rnd
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDerive(t *testing.T) {
+	a, b, c := Derive("foo"), Derive("foo"), Derive("bar")
+	for i := 0; i < 10; i++ {
+		x, y, z := a.Uint64(), b.Uint64(), c.Uint64()
+		if x != y {
+			t.Fatalf("Derive(%q) returned different streams: %d != %d", "foo", x, y)
+		}
+		if x == z {
+			t.Fatalf("Derive(%q) and Derive(%q) returned the same value %d", "foo", "bar", x)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	a := Derive("foo")
+	b := a.Split()
+	c := a.Split()
+	for i := 0; i < 10; i++ {
+		x, y, z := a.Uint64(), b.Uint64(), c.Uint64()
+		if x == y || x == z || y == z {
+			t.Fatalf("Split returned correlated streams: %d, %d, %d", x, y, z)
+		}
+	}
+}
+
+func TestSplitOptions(t *testing.T) {
+	for _, b := range []Backend{ChaCha8, PCG, Xoshiro256} {
+		child := New(WithBackend(b)).Split().Split()
+		data, err := child.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary of child of %v: %v", b, err)
+		}
+		if got := Backend(data[len(randMagic)]); got != b {
+			t.Errorf("child of %v generator uses %v", b, got)
+		}
+		var restored Rand
+		if err := restored.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		data, err = restored.Split().MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Backend(data[len(randMagic)]); got != b {
+			t.Errorf("child of unmarshaled %v generator uses %v", b, got)
+		}
+
+		p := ReseedPolicy{After: 10}
+		child = New(WithBackend(b), WithReseedPolicy(p)).Split()
+		s, ok := child.src.(*reseedSource)
+		if !ok || s.policy != p || s.backend != b {
+			t.Fatalf("child of %v generator with %v does not re-seed", b, p)
+		}
+		for range p.After + 1 {
+			child.Uint64()
+		}
+		if s.n != 1 {
+			t.Errorf("child of %v generator with %v did not re-seed after %d values", b, p, p.After)
+		}
+	}
+	if _, ok := New(WithBufferedBatch(4)).Split().src.(*batchSource); !ok {
+		t.Errorf("child of generator with WithBufferedBatch does not buffer")
+	}
+	if _, ok := ForKey([]byte("foo")).Split().src.(*rand.ChaCha8); !ok {
+		t.Errorf("child of ForKey does not use ChaCha8")
+	}
+}
+
+func TestNew(t *testing.T) {
+	a, b := New(), New()
+	if a.Uint64() == b.Uint64() {
+		t.Fatal("New returned generators producing the same value")
+	}
+}
+
+func TestRandUint128(t *testing.T) {
+	a, b := Derive("foo"), Derive("foo")
+	hi, lo := a.Uint128()
+	if x, y := b.Uint64(), b.Uint64(); hi != x || lo != y {
+		t.Errorf("Uint128() = %d, %d, want %d, %d", hi, lo, x, y)
+	}
+}
@@ -0,0 +1,95 @@
+package rnd
+
+import "io"
+
+// BufferedReader returns an io.Reader of random bytes, which draws from the
+// global source in batches of size bytes. This makes many small reads
+// cheaper than calling Read, which synchronizes on every call.
+//
+// Unlike Read, the returned reader is not safe for concurrent use. Its Read
+// method always fills the whole buffer and returns a nil error. It panics if
+// size <= 0.
+func BufferedReader(size int) io.Reader {
+	if size <= 0 {
+		panic("invalid argument to BufferedReader")
+	}
+	buf := make([]byte, size)
+	return &bufferedReader{buf: buf, pos: size}
+}
+
+type bufferedReader struct {
+	buf []byte
+	// pos is the offset of the first unused byte in buf.
+	pos int
+}
+
+func (r *bufferedReader) Read(p []byte) (n int, err error) {
+	// Large reads bypass the buffer.
+	if len(p) >= len(r.buf) {
+		return Read(p)
+	}
+	for n < len(p) {
+		if r.pos == len(r.buf) {
+			Read(r.buf)
+			r.pos = 0
+		}
+		m := copy(p[n:], r.buf[r.pos:])
+		r.pos += m
+		n += m
+	}
+	return n, nil
+}
+
+// writeChunk is the size of the buffer used by WriteTo.
+const writeChunk = 32 << 10
+
+// WriteTo writes n random bytes to w, in chunks, so it does not need to hold
+// all of them in memory. It returns the number of bytes written and the first
+// error encountered while writing, if any. It panics if n < 0.
+func WriteTo(w io.Writer, n int64) (int64, error) {
+	if n < 0 {
+		panic("invalid argument to WriteTo")
+	}
+	buf := make([]byte, min(n, writeChunk))
+	var written int64
+	for written < n {
+		p := buf[:min(n-written, int64(len(buf)))]
+		Read(p)
+		m, err := w.Write(p)
+		written += int64(m)
+		if err != nil {
+			return written, err
+		}
+		if m < len(p) {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
+}
+
+// NewReader returns an io.Reader that yields exactly n random bytes and then
+// returns io.EOF. It is meant for request bodies or file contents in tests.
+// The reader is not safe for concurrent use. It panics if n < 0.
+func NewReader(n int64) io.Reader {
+	if n < 0 {
+		panic("invalid argument to NewReader")
+	}
+	return &limitedReader{n: n}
+}
+
+type limitedReader struct {
+	// n is the number of remaining bytes.
+	n int64
+}
+
+func (r *limitedReader) Read(p []byte) (n int, err error) {
+	if r.n == 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.n {
+		p = p[:r.n]
+	}
+	Read(p)
+	r.n -= int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,70 @@
+package rnd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBufferedReader(t *testing.T) {
+	r := BufferedReader(64)
+	var prev []byte
+	for _, n := range []int{0, 1, 7, 16, 63, 64, 100, 3} {
+		p := make([]byte, n)
+		if m, err := r.Read(p); m != n || err != nil {
+			t.Fatalf("Read(%d bytes) = %d, %v, want %d, <nil>", n, m, err, n)
+		}
+		if n >= 16 && bytes.Equal(p, prev[:min(len(prev), n)]) {
+			t.Fatalf("Read returned the same bytes twice: %x", p)
+		}
+		prev = p
+	}
+}
+
+// limitWriter fails with errLimit after n bytes.
+type limitWriter struct{ n int }
+
+var errLimit = errors.New("limit reached")
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errLimit
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestWriteTo(t *testing.T) {
+	for _, n := range []int64{0, 1, writeChunk, 3*writeChunk + 17} {
+		var buf bytes.Buffer
+		if m, err := WriteTo(&buf, n); m != n || err != nil || int64(buf.Len()) != n {
+			t.Errorf("WriteTo(_, %d) = %d, %v and wrote %d bytes", n, m, err, buf.Len())
+		}
+	}
+	if m, err := WriteTo(&limitWriter{n: 100000}, 1<<20); m != 100000 || err != errLimit {
+		t.Errorf("WriteTo(limitWriter(100000), 1<<20) = %d, %v, want 100000, %v", m, err, errLimit)
+	}
+	if m, err := WriteTo(io.Discard, 1<<20); m != 1<<20 || err != nil {
+		t.Errorf("WriteTo(io.Discard, 1<<20) = %d, %v", m, err)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	for _, n := range []int64{0, 1, 1000, 100000} {
+		b, err := io.ReadAll(NewReader(n))
+		if int64(len(b)) != n || err != nil {
+			t.Errorf("ReadAll(NewReader(%d)) = %d bytes, %v", n, len(b), err)
+		}
+	}
+	r := NewReader(3)
+	p := make([]byte, 10)
+	if n, err := r.Read(p); n != 3 || err != nil {
+		t.Errorf("Read = %d, %v, want 3, <nil>", n, err)
+	}
+	if n, err := r.Read(p); n != 0 || err != io.EOF {
+		t.Errorf("Read = %d, %v, want 0, EOF", n, err)
+	}
+}
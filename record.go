@@ -0,0 +1,90 @@
+package rnd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand/v2"
+)
+
+// recorder is a rand.Source, writing all values produced by src to w.
+type recorder struct {
+	src rand.Source
+	w   *bufio.Writer
+	err error
+}
+
+func (r *recorder) Uint64() uint64 {
+	v := r.src.Uint64()
+	if r.err == nil {
+		r.err = binary.Write(r.w, binary.LittleEndian, v)
+	}
+	return v
+}
+
+// Record starts recording all values produced by the global source to w, as
+// little-endian 64-bit integers. Recording continues across re-seeds. It
+// stops when the returned function is called, which returns the first error
+// writing to w, if any.
+//
+// The recording can be passed to Replay, to exactly reproduce the values
+// returned by the package-level functions in a later run, as long as the
+// program calls them in the same order. While recording, functions which
+// usually draw from pooled generators, like WithRand, FillDist or Cycle,
+// instead seed a new generator from the global source for every call, so
+// they are reproduced as well.
+//
+// Record is meant for debugging non-deterministic failures. Note that the
+// recording reveals all random values produced, so it must be treated as
+// sensitive.
+//
+// Record panics if a recording is already in progress.
+func Record(w io.Writer) (stop func() error) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	if src.rec != nil {
+		panic("rnd: Record called while already recording")
+	}
+	rec := &recorder{src: src.src, w: bufio.NewWriter(w)}
+	src.rec, src.src = rec, rec
+	recording.Store(true)
+	return func() error {
+		src.mu.Lock()
+		defer src.mu.Unlock()
+		if src.rec != rec {
+			return errors.New("rnd: recording already stopped")
+		}
+		src.src, src.rec = rec.src, nil
+		recording.Store(false)
+		if err := rec.w.Flush(); rec.err == nil {
+			rec.err = err
+		}
+		return rec.err
+	}
+}
+
+// replayer is a rand.Source, reading values recorded by Record.
+type replayer struct {
+	r *bufio.Reader
+}
+
+func (r replayer) Uint64() uint64 {
+	var v uint64
+	if err := binary.Read(r.r, binary.LittleEndian, &v); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			panic("rnd: replayed recording exhausted")
+		}
+		panic("rnd: reading replayed recording: " + err.Error())
+	}
+	return v
+}
+
+// Replay replaces the global source by one returning the values recorded by
+// Record, read from r. The package-level functions panic, once the recording
+// is exhausted. Like SetSource, Replay must be called before the global
+// source is first used and panics otherwise. SetSource(nil) restores the
+// default source.
+func Replay(r io.Reader) {
+	SetSource(replayer{bufio.NewReader(r)})
+}
@@ -0,0 +1,53 @@
+package rnd
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	draw := func() []uint64 {
+		var p [13]byte
+		Read(p[:])
+		hi, lo := Uint128()
+		v := []uint64{Uint64(), uint64(Intn(1000)), hi, lo, uint64(p[12])}
+		// Functions using pooled generators are reproduced as well.
+		for _, i := range Cycle(8) {
+			v = append(v, uint64(i))
+		}
+		f := make([]float64, 3)
+		FillDist(f, NormalDist{Sigma: 1})
+		for _, x := range f {
+			v = append(v, math.Float64bits(x))
+		}
+		return append(v, NewZipf(2, 1, 100).Next())
+	}
+
+	var buf bytes.Buffer
+	stop := Record(&buf)
+	want := draw()
+	Reseed()
+	want = append(want, draw()...)
+	if err := stop(); err != nil {
+		t.Fatalf("stop() = %v", err)
+	}
+	if err := stop(); err == nil {
+		t.Error("second call to stop() succeeded")
+	}
+
+	Replay(bytes.NewReader(buf.Bytes()))
+	defer SetSource(nil)
+	got := draw()
+	got = append(got, draw()...)
+	if !slices.Equal(got, want) {
+		t.Fatalf("replayed values %v, want %v", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("exhausted replay did not panic")
+		}
+	}()
+	Uint64()
+}
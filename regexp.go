@@ -0,0 +1,159 @@
+package rnd
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// maxRepeat is the maximum number of additional repetitions generated for
+// unbounded repetitions like * and +.
+const maxRepeat = 10
+
+// regexpCache caches compiled generators by pattern.
+var regexpCache sync.Map // map[string]func(*strings.Builder)
+
+// errNoMatch is returned when compiling a regular expression that can not
+// match any string.
+var errNoMatch = errors.New("pattern does not match any string")
+
+// FromRegexp returns a random string matching the regular expression pattern,
+// which uses the syntax accepted by package regexp.
+//
+// Unbounded repetitions (*, + and {n,}) are repeated at most 10 times more
+// than their minimum. Any character (.) is chosen from all valid runes. Empty
+// assertions like ^, $ and \b are ignored, so the returned string might not
+// actually match pattern if they are used in the middle of it.
+//
+// Compiled patterns are cached, so repeated calls with the same pattern are
+// cheap.
+func FromRegexp(pattern string) (string, error) {
+	gen, ok := regexpCache.Load(pattern)
+	if !ok {
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil {
+			return "", err
+		}
+		g, err := compileRegexp(re)
+		if err != nil {
+			return "", fmt.Errorf("rnd: %w", err)
+		}
+		gen, _ = regexpCache.LoadOrStore(pattern, g)
+	}
+	var sb strings.Builder
+	gen.(func(*strings.Builder))(&sb)
+	return sb.String(), nil
+}
+
+// compileRegexp returns a function writing random strings matching re.
+func compileRegexp(re *syntax.Regexp) (func(*strings.Builder), error) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return nil, errNoMatch
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return func(*strings.Builder) {}, nil
+	case syntax.OpLiteral:
+		runes := re.Rune
+		if re.Flags&syntax.FoldCase == 0 {
+			s := string(runes)
+			return func(sb *strings.Builder) { sb.WriteString(s) }, nil
+		}
+		folds := make([][]rune, len(runes))
+		for i, r := range runes {
+			folds[i] = []rune{r}
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				folds[i] = append(folds[i], f)
+			}
+		}
+		return func(sb *strings.Builder) {
+			for _, f := range folds {
+				sb.WriteRune(f[Intn(len(f))])
+			}
+		}, nil
+	case syntax.OpCharClass:
+		var ivs []runeInterval
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			ivs = append(ivs, runeInterval{re.Rune[i], re.Rune[i+1]})
+		}
+		return runeSetGen(newRuneSetFromIntervals(ivs))
+	case syntax.OpAnyCharNotNL:
+		return runeSetGen(newRuneSetFromIntervals([]runeInterval{{0, '\n' - 1}, {'\n' + 1, unicode.MaxRune}}))
+	case syntax.OpAnyChar:
+		return runeSetGen(newRuneSetFromIntervals([]runeInterval{{0, unicode.MaxRune}}))
+	case syntax.OpCapture:
+		return compileRegexp(re.Sub[0])
+	case syntax.OpStar:
+		return repeatGen(re.Sub[0], 0, -1)
+	case syntax.OpPlus:
+		return repeatGen(re.Sub[0], 1, -1)
+	case syntax.OpQuest:
+		return repeatGen(re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		return repeatGen(re.Sub[0], re.Min, re.Max)
+	case syntax.OpConcat:
+		gens := make([]func(*strings.Builder), len(re.Sub))
+		for i, sub := range re.Sub {
+			g, err := compileRegexp(sub)
+			if err != nil {
+				return nil, err
+			}
+			gens[i] = g
+		}
+		return func(sb *strings.Builder) {
+			for _, g := range gens {
+				g(sb)
+			}
+		}, nil
+	case syntax.OpAlternate:
+		var gens []func(*strings.Builder)
+		for _, sub := range re.Sub {
+			g, err := compileRegexp(sub)
+			if err == errNoMatch {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			gens = append(gens, g)
+		}
+		if len(gens) == 0 {
+			return nil, errNoMatch
+		}
+		return func(sb *strings.Builder) {
+			gens[Intn(len(gens))](sb)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported regular expression operator %v", re.Op)
+	}
+}
+
+func runeSetGen(s *runeSet) (func(*strings.Builder), error) {
+	if len(s.cum) == 0 {
+		return nil, errNoMatch
+	}
+	return func(sb *strings.Builder) { sb.WriteRune(s.pick()) }, nil
+}
+
+// repeatGen returns a function writing between lo and hi repetitions of
+// sub. If hi is -1, the repetition is unbounded.
+func repeatGen(sub *syntax.Regexp, lo, hi int) (func(*strings.Builder), error) {
+	if hi < 0 {
+		hi = lo + maxRepeat
+	}
+	g, err := compileRegexp(sub)
+	if err == errNoMatch && lo == 0 {
+		return func(*strings.Builder) {}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return func(sb *strings.Builder) {
+		n := lo + Intn(hi-lo+1)
+		for i := 0; i < n; i++ {
+			g(sb)
+		}
+	}, nil
+}
@@ -0,0 +1,41 @@
+package rnd
+
+import (
+	"regexp"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFromRegexp(t *testing.T) {
+	patterns := []string{
+		``,
+		`abc`,
+		`(?i)hello, world`,
+		`^[a-z_][a-z0-9_]{0,15}$`,
+		`\+49 \d{3} \d{4,8}`,
+		`(/[^/\x00]+)+/?`,
+		`a*b+c?d{2}e{3,}`,
+		`foo|bar|baz`,
+		`[[:alpha:]]\pL\p{Greek}\PL`,
+		`(?s).{5}`,
+		`.{5}`,
+		`x|[^\x00-\x{10FFFF}]`,
+	}
+	for _, p := range patterns {
+		re := regexp.MustCompile(`^(?:` + p + `)$`)
+		for i := 0; i < 100; i++ {
+			s, err := FromRegexp(p)
+			if err != nil {
+				t.Fatalf("FromRegexp(%q) = %v", p, err)
+			}
+			if !utf8.ValidString(s) || !re.MatchString(s) {
+				t.Fatalf("FromRegexp(%q) = %q, does not match", p, s)
+			}
+		}
+	}
+	for _, p := range []string{`(`, `[^\x00-\x{10FFFF}]`} {
+		if s, err := FromRegexp(p); err == nil {
+			t.Errorf("FromRegexp(%q) = %q, <nil>, want error", p, s)
+		}
+	}
+}
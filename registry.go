@@ -0,0 +1,56 @@
+package rnd
+
+import "sync"
+
+// Registry hands out one independent generator per name, so every subsystem
+// of a program can use its own stream, without contending for the lock of the
+// global source. It is the single place to decide how these generators are
+// seeded.
+//
+// The zero Registry is ready to use. It seeds every generator from the global
+// source, when it is first requested. Use NewSeededRegistry to make the
+// streams reproducible. A Registry is safe for concurrent use, but the
+// generators it returns are not.
+type Registry struct {
+	mu     sync.Mutex
+	seed   []byte
+	seeded bool
+	rands  map[string]*Rand
+}
+
+// NewSeededRegistry returns a Registry, whose generators are a pure function
+// of seed and their name. In particular, they do not depend on the order in
+// which names are first requested. The generator for name is the same as
+// ForKeySalted([]byte(name), seed) would return. seed is not retained and
+// should be secret, if the streams must not be predictable.
+func NewSeededRegistry(seed []byte) *Registry {
+	return &Registry{
+		seed:   append([]byte{}, seed...),
+		seeded: true,
+	}
+}
+
+// Get returns the generator for name. The first call for a name creates it,
+// later calls return the same *Rand, so callers sharing a name must
+// synchronize their use of it. Different names give statistically
+// independent streams.
+func (reg *Registry) Get(name string) *Rand {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if r, ok := reg.rands[name]; ok {
+		return r
+	}
+	var r *Rand
+	if reg.seeded {
+		r = ForKeySalted([]byte(name), reg.seed)
+	} else {
+		var seed [32]byte
+		Read(seed[:])
+		r = newRand(seed)
+	}
+	if reg.rands == nil {
+		reg.rands = make(map[string]*Rand)
+	}
+	reg.rands[name] = r
+	return r
+}
@@ -0,0 +1,31 @@
+package rnd
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	var reg Registry
+	a, b := reg.Get("a"), reg.Get("b")
+	if a == b {
+		t.Fatal(`Get("a") == Get("b")`)
+	}
+	if reg.Get("a") != a {
+		t.Fatal(`Get("a") is not memoized`)
+	}
+	if a.Uint64() == b.Uint64() {
+		t.Error(`Get("a") and Get("b") produce the same stream`)
+	}
+
+	seed := []byte("seed")
+	r1, r2 := NewSeededRegistry(seed), NewSeededRegistry(seed)
+	// Request names in different orders.
+	r2.Get("y")
+	x1, x2 := r1.Get("x"), r2.Get("x")
+	for range 10 {
+		if v1, v2 := x1.Uint64(), x2.Uint64(); v1 != v2 {
+			t.Fatalf("seeded registries differ: %#x != %#x", v1, v2)
+		}
+	}
+	if NewSeededRegistry([]byte("other")).Get("x").Uint64() == NewSeededRegistry(seed).Get("x").Uint64() {
+		t.Error("registries with different seeds produce the same stream")
+	}
+}
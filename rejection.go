@@ -0,0 +1,64 @@
+package rnd
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// RejectionDist is a distribution sampled by rejection sampling. It is safe
+// for concurrent use, if its density functions are.
+type RejectionDist struct {
+	pdf      func(x float64) float64
+	proposal Density
+	m        float64
+
+	proposed, accepted atomic.Uint64
+}
+
+// Rejection returns the distribution with the (possibly unnormalized) density
+// pdf, sampled by rejection sampling: it draws x from proposal and accepts
+// it with probability pdf(x)/(m·proposal.PDF(x)), repeating until a value is
+// accepted.
+//
+// This is only correct if pdf(x) ≤ m·proposal.PDF(x) for all x. Sample panics,
+// if it observes a violation of that bound, instead of silently returning
+// biased values. The expected number of proposals per value is m divided by
+// the integral of pdf, so m should be as small as possible.
+//
+// Rejection panics if m is not positive and finite.
+func Rejection(pdf func(x float64) float64, proposal Density, m float64) *RejectionDist {
+	if !(m > 0) || math.IsInf(m, 1) {
+		panic("invalid argument to Rejection")
+	}
+	return &RejectionDist{pdf: pdf, proposal: proposal, m: m}
+}
+
+// Sample draws a value from d.
+func (d *RejectionDist) Sample(g Generator) float64 {
+	for {
+		x := d.proposal.Sample(g)
+		d.proposed.Add(1)
+		p, env := d.pdf(x), d.m*d.proposal.PDF(x)
+		if p > env {
+			panic("rnd: density exceeds envelope in Rejection")
+		}
+		// Accept with probability p/env, without dividing by env, which can
+		// be 0 if p is.
+		if g.Float64()*env < p {
+			d.accepted.Add(1)
+			return x
+		}
+	}
+}
+
+// AcceptanceRate returns the fraction of proposals accepted so far, or 0 if
+// no value has been drawn. If pdf is normalized, it converges to 1/m.
+func (d *RejectionDist) AcceptanceRate() float64 {
+	// Load accepted first, so it does not exceed proposed.
+	a := d.accepted.Load()
+	p := d.proposed.Load()
+	if p == 0 {
+		return 0
+	}
+	return float64(a) / float64(p)
+}
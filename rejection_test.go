@@ -0,0 +1,55 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRejection(t *testing.T) {
+	// The semicircle density on [-1,1], with mean 0 and variance 1/4.
+	semi := func(x float64) float64 { return 2 / math.Pi * math.Sqrt(max(1-x*x, 0)) }
+	m := 4 / math.Pi
+	d := Rejection(semi, UniformDist{Min: -1, Max: 1}, m)
+	const n = 20000
+	dst := make([]float64, n)
+	FillDist(dst, d)
+	var sq float64
+	for _, v := range dst {
+		if v < -1 || v >= 1 {
+			t.Fatalf("Rejection drew %v", v)
+		}
+		sq += v * v
+	}
+	if v := sq / n; math.Abs(v-0.25) > 0.01 {
+		t.Errorf("variance = %v, want 0.25", v)
+	}
+	if r := d.AcceptanceRate(); math.Abs(r-1/m) > 0.02 {
+		t.Errorf("AcceptanceRate() = %v, want %v", r, 1/m)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Sample with violated envelope did not panic")
+		}
+	}()
+	for range 100 {
+		Rejection(semi, UniformDist{Min: -1, Max: 1}, 1).Sample(Global())
+	}
+}
+
+func TestPDF(t *testing.T) {
+	for _, tc := range []struct {
+		d    Density
+		x, p float64
+	}{
+		{UniformDist{Min: 1, Max: 3}, 2, 0.5},
+		{UniformDist{Min: 1, Max: 3}, 3, 0},
+		{NormalDist{Mu: 1, Sigma: 2}, 1, 1 / (2 * math.Sqrt(2*math.Pi))},
+		{ExponentialDist{Rate: 2}, 0, 2},
+		{ExponentialDist{Rate: 2}, -1, 0},
+	} {
+		if p := tc.d.PDF(tc.x); math.Abs(p-tc.p) > 1e-12 {
+			t.Errorf("%#v.PDF(%v) = %v, want %v", tc.d, tc.x, p, tc.p)
+		}
+	}
+}
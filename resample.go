@@ -0,0 +1,55 @@
+package rnd
+
+// Bootstrap returns the bootstrap distribution of stat over s: for each of
+// iters iterations, it draws len(s) elements of s with replacement and calls
+// stat on them. The results can be used to estimate confidence intervals, for
+// example by sorting them and taking percentiles.
+//
+// The slice passed to stat is reused between iterations, so stat must not
+// retain or modify it. Bootstrap panics if iters < 0 or s is empty.
+func Bootstrap[T any](s []T, iters int, stat func([]T) float64) []float64 {
+	if iters < 0 || len(s) == 0 {
+		panic("invalid argument to Bootstrap")
+	}
+	out := make([]float64, 0, iters)
+	buf := make([]T, len(s))
+	for range iters {
+		WithRand(func(r *Rand) {
+			for i := range buf {
+				buf[i] = s[r.Intn(len(s))]
+			}
+		})
+		out = append(out, stat(buf))
+	}
+	return out
+}
+
+// PermutationTest returns the p-value of a permutation test of the null
+// hypothesis that a and b are drawn from the same distribution. It compares
+// stat(a, b) to the values of stat, after randomly reassigning the pooled
+// observations to groups of the original sizes, iters times.
+//
+// The p-value is the fraction of reassignments for which stat is at least as
+// large as for the original groups, counting the original assignment itself,
+// so it is never 0. To test whether the groups differ in either direction, stat
+// should return an absolute value, like the absolute difference of means.
+//
+// The slices passed to stat are reused between iterations, so stat must not
+// retain or modify them. PermutationTest panics if iters < 0.
+func PermutationTest(a, b []float64, iters int, stat func(a, b []float64) float64) float64 {
+	if iters < 0 {
+		panic("invalid argument to PermutationTest")
+	}
+	obs := stat(a, b)
+	pool := make([]float64, 0, len(a)+len(b))
+	pool = append(pool, a...)
+	pool = append(pool, b...)
+	n := 1
+	for range iters {
+		Shuffle(pool)
+		if stat(pool[:len(a):len(a)], pool[len(a):]) >= obs {
+			n++
+		}
+	}
+	return float64(n) / float64(iters+1)
+}
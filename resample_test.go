@@ -0,0 +1,52 @@
+package rnd
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func mean(s []float64) float64 {
+	var sum float64
+	for _, v := range s {
+		sum += v
+	}
+	return sum / float64(len(s))
+}
+
+func TestBootstrap(t *testing.T) {
+	s := make([]float64, 100)
+	for i := range s {
+		s[i] = float64(i)
+	}
+	dist := Bootstrap(s, 2000, mean)
+	if len(dist) != 2000 {
+		t.Fatalf("len(Bootstrap(…, 2000, …)) = %d", len(dist))
+	}
+	// The standard error of the mean is σ/√n ≈ 2.9.
+	slices.Sort(dist)
+	lo, hi := dist[50], dist[1950]
+	if math.Abs(lo-(49.5-1.96*2.9)) > 1 || math.Abs(hi-(49.5+1.96*2.9)) > 1 {
+		t.Errorf("95%% bootstrap interval of the mean is [%v, %v], want about [43.8, 55.2]", lo, hi)
+	}
+}
+
+func TestPermutationTest(t *testing.T) {
+	diff := func(a, b []float64) float64 { return math.Abs(mean(a) - mean(b)) }
+	a, b := make([]float64, 50), make([]float64, 50)
+	for i := range a {
+		a[i], b[i] = NormFloat64(), NormFloat64()+2
+	}
+	if p := PermutationTest(a, b, 1000, diff); p > 0.01 {
+		t.Errorf("p-value for different means = %v, want < 0.01", p)
+	}
+	for i := range b {
+		b[i] = NormFloat64()
+	}
+	if p := PermutationTest(a, a, 1000, diff); p != 1 {
+		t.Errorf("p-value for identical groups = %v, want 1", p)
+	}
+	if p := PermutationTest(a, b, 0, diff); p != 1 {
+		t.Errorf("p-value with 0 iterations = %v, want 1", p)
+	}
+}
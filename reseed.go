@@ -0,0 +1,67 @@
+package rnd
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Reason describes why the global source has been re-seeded.
+type Reason int
+
+const (
+	// ReasonCount means the global source has produced a fixed number of
+	// values since it has last been seeded.
+	ReasonCount Reason = iota + 1
+	// ReasonForced means Reseed has been called.
+	ReasonForced
+	// ReasonDebug means EnableDebugSeeding has seeded the global source.
+	ReasonDebug
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonCount:
+		return "count"
+	case ReasonForced:
+		return "forced"
+	case ReasonDebug:
+		return "debug"
+	}
+	return "Reason(" + strconv.Itoa(int(r)) + ")"
+}
+
+var reseedHooks struct {
+	mu  sync.Mutex
+	fns []func(Reason)
+}
+
+// OnReseed registers fn to be called whenever the global source is
+// re-seeded. It is meant for logging and auditing.
+//
+// fn is called synchronously, by the goroutine triggering the re-seed, so it
+// should return quickly. It may use the package-level functions.
+func OnReseed(fn func(reason Reason)) {
+	reseedHooks.mu.Lock()
+	defer reseedHooks.mu.Unlock()
+	reseedHooks.fns = append(reseedHooks.fns, fn)
+}
+
+func notifyReseed(reason Reason) {
+	reseedHooks.mu.Lock()
+	fns := reseedHooks.fns
+	reseedHooks.mu.Unlock()
+	for _, fn := range fns {
+		fn(reason)
+	}
+}
+
+// Reseed re-seeds the global source immediately. It does nothing, if the
+// global source has been seeded by EnableDebugSeeding or replaced by
+// SetSource.
+func Reseed() {
+	if debugSeeded.Load() || customSource.Load() {
+		return
+	}
+	stats.retired.Add(calls.reset())
+	rekey(ReasonForced)
+}
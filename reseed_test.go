@@ -0,0 +1,16 @@
+package rnd
+
+import "testing"
+
+func TestOnReseed(t *testing.T) {
+	if debugSeeded.Load() {
+		t.Skip("debug seeding is enabled")
+	}
+	var got []Reason
+	OnReseed(func(r Reason) { got = append(got, r) })
+	Reseed()
+	reseed(1 << 32)
+	if len(got) != 2 || got[0] != ReasonForced || got[1] != ReasonCount {
+		t.Fatalf("OnReseed hook got %v, want [forced count]", got)
+	}
+}
@@ -0,0 +1,173 @@
+package rnd
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"errors"
+	"io"
+	"math"
+	"sync"
+)
+
+// WeightedReservoir maintains a weighted random sample of bounded size of a
+// stream of items, without replacement. The probability of an item to be
+// part of the sample is proportional to its weight, in the same way as for
+// WeightedShuffle. It is safe for concurrent use.
+//
+// WeightedReservoir implements algorithm A-ExpJ, see Efraimidis and
+// Spirakis, "Weighted random sampling with a reservoir", 2006. It uses O(k)
+// memory and draws O(k·log(n/k)) random numbers for n items.
+type WeightedReservoir[T any] struct {
+	mu sync.Mutex
+	k  int
+	h  reservoirHeap[T]
+	// skip is the remaining weight to skip, before the next item enters the
+	// reservoir.
+	skip float64
+}
+
+// NewWeightedReservoir returns a WeightedReservoir holding up to k items. It
+// panics if k <= 0.
+func NewWeightedReservoir[T any](k int) *WeightedReservoir[T] {
+	if k <= 0 {
+		panic("invalid argument to NewWeightedReservoir")
+	}
+	return &WeightedReservoir[T]{k: k, h: make(reservoirHeap[T], 0, k)}
+}
+
+// Add offers item with weight w to the reservoir. Items with weight 0 are
+// never sampled. It panics if w is negative, NaN or infinite.
+func (r *WeightedReservoir[T]) Add(item T, w float64) {
+	if !(w >= 0) || math.IsInf(w, 1) {
+		panic("invalid weight in WeightedReservoir.Add")
+	}
+	if w == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Keys are log(u^(1/w)), for u uniform in (0,1). The sample consists of
+	// the items with the largest keys.
+	if len(r.h) < r.k {
+		heap.Push(&r.h, reservoirItem[T]{math.Log(Float64OO()) / w, item})
+		if len(r.h) == r.k {
+			r.nextSkip()
+		}
+		return
+	}
+	if r.skip -= w; r.skip > 0 {
+		return
+	}
+	// The item enters the reservoir. Its key is drawn conditional on being
+	// larger than the current minimum.
+	t := math.Exp(r.h[0].key * w)
+	u := t + Float64OO()*(1-t)
+	r.h[0] = reservoirItem[T]{math.Log(u) / w, item}
+	heap.Fix(&r.h, 0)
+	r.nextSkip()
+}
+
+// nextSkip draws the weight to skip until the next item enters the
+// reservoir. r.mu must be held.
+func (r *WeightedReservoir[T]) nextSkip() {
+	r.skip = math.Log(Float64OO()) / r.h[0].key
+}
+
+// Sample returns the items currently in the reservoir, in no particular
+// order. If fewer than k items with positive weight have been added, all of
+// them are returned.
+func (r *WeightedReservoir[T]) Sample() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]T, len(r.h))
+	for i, it := range r.h {
+		out[i] = it.item
+	}
+	return out
+}
+
+type reservoirItem[T any] struct {
+	key  float64
+	item T
+}
+
+// reservoirHeap is a min-heap of items by key.
+type reservoirHeap[T any] []reservoirItem[T]
+
+func (h reservoirHeap[T]) Len() int           { return len(h) }
+func (h reservoirHeap[T]) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h reservoirHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *reservoirHeap[T]) Push(x any)        { *h = append(*h, x.(reservoirItem[T])) }
+func (h *reservoirHeap[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// SampleLines returns a uniform random sample of k lines read from r, without
+// replacement, in no particular order. If r contains fewer than k lines, all
+// of them are returned. Lines are split like by bufio.ScanLines, so their
+// trailing end-of-line markers are removed, but there is no limit on their
+// length.
+//
+// SampleLines implements algorithm L, see Li, "Reservoir-sampling algorithms
+// of time complexity O(n(1+log(N/n)))", 1994. It uses O(k) memory and only
+// draws random numbers for the lines which enter the sample, so it can
+// efficiently sample from huge inputs like log files.
+//
+// SampleLines panics if k <= 0. If reading from r fails, it returns the error,
+// together with a sample of the lines read so far.
+func SampleLines(r io.Reader, k int) ([]string, error) {
+	if k <= 0 {
+		panic("invalid argument to SampleLines")
+	}
+	br := bufio.NewReader(r)
+	sample := make([]string, 0, k)
+	for len(sample) < k {
+		line, ok, err := readLine(br, true)
+		if err != nil || !ok {
+			return sample, err
+		}
+		sample = append(sample, line)
+	}
+	w := math.Exp(math.Log(Float64OO()) / float64(k))
+	for {
+		// Skip a geometrically distributed number of lines.
+		skip := math.Floor(math.Log(Float64OO()) / math.Log1p(-w))
+		for n := min(skip, math.MaxInt64); n > 0; n-- {
+			if _, ok, err := readLine(br, false); err != nil || !ok {
+				return sample, err
+			}
+		}
+		line, ok, err := readLine(br, true)
+		if err != nil || !ok {
+			return sample, err
+		}
+		sample[Intn(k)] = line
+		w *= math.Exp(math.Log(Float64OO()) / float64(k))
+	}
+}
+
+// readLine reads the next line from br. It reports false, if there are no
+// more lines. If keep is false, the line is discarded without allocating.
+func readLine(br *bufio.Reader, keep bool) (line string, ok bool, err error) {
+	var buf []byte
+	for {
+		b, err := br.ReadSlice('\n')
+		if keep {
+			buf = append(buf, b...)
+		}
+		ok = ok || len(b) > 0
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return "", false, err
+		}
+		buf = bytes.TrimSuffix(bytes.TrimSuffix(buf, []byte("\n")), []byte("\r"))
+		return string(buf), ok, nil
+	}
+}
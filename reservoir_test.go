@@ -0,0 +1,105 @@
+package rnd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestWeightedReservoir(t *testing.T) {
+	r := NewWeightedReservoir[int](3)
+	r.Add(1, 1)
+	r.Add(2, 0)
+	if s := r.Sample(); len(s) != 1 || s[0] != 1 {
+		t.Fatalf("Sample() = %v, want [1]", s)
+	}
+
+	// With a sample of size 1, items are chosen proportional to weight.
+	const n = 10000
+	var counts [4]int
+	for i := 0; i < n; i++ {
+		r := NewWeightedReservoir[int](1)
+		for j := 0; j < 100; j++ {
+			r.Add(3, 1)
+		}
+		r.Add(0, 100)
+		r.Add(1, 0)
+		r.Add(2, 300)
+		counts[r.Sample()[0]]++
+	}
+	if counts[1] != 0 {
+		t.Errorf("item with weight 0 sampled %d times", counts[1])
+	}
+	for i, want := range []float64{0.2, 0, 0.6, 0.2} {
+		if f := float64(counts[i]) / n; math.Abs(f-want) > 0.02 {
+			t.Errorf("item %d sampled with frequency %v, want %v", i, f, want)
+		}
+	}
+
+	r = NewWeightedReservoir[int](10)
+	for i := 0; i < 1000; i++ {
+		r.Add(i, float64(i%7))
+	}
+	seen := make(map[int]bool)
+	for _, v := range r.Sample() {
+		if seen[v] || v%7 == 0 {
+			t.Fatalf("invalid Sample() %v", r.Sample())
+		}
+		seen[v] = true
+	}
+	if len(seen) != 10 {
+		t.Errorf("len(Sample()) = %d, want 10", len(seen))
+	}
+}
+
+func TestSampleLines(t *testing.T) {
+	const (
+		n     = 100
+		k     = 10
+		iters = 2000
+	)
+	var sb strings.Builder
+	for i := range n {
+		fmt.Fprintf(&sb, "%d\r\n", i)
+	}
+	input := sb.String()
+	var counts [n]int
+	for range iters {
+		lines, err := SampleLines(strings.NewReader(input), k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lines) != k {
+			t.Fatalf("SampleLines returned %d lines, want %d", len(lines), k)
+		}
+		seen := make(map[int]bool)
+		for _, l := range lines {
+			i, err := strconv.Atoi(l)
+			if err != nil || seen[i] {
+				t.Fatalf("SampleLines returned invalid or duplicate line %q", l)
+			}
+			seen[i] = true
+			counts[i]++
+		}
+	}
+	// Every line is sampled with probability k/n, so about 200 times.
+	for i, c := range counts {
+		if c < 140 || c > 260 {
+			t.Errorf("line %d was sampled %d times, want about %d", i, c, iters*k/n)
+		}
+	}
+
+	long := strings.Repeat("x", 100000)
+	lines, err := SampleLines(strings.NewReader("a\n"+long), 5)
+	if err != nil || len(lines) != 2 || lines[0] != "a" || lines[1] != long {
+		t.Errorf("SampleLines with short input returned %d lines, %v", len(lines), err)
+	}
+	errTest := errors.New("test")
+	if _, err := SampleLines(iotest.ErrReader(errTest), 1); err != errTest {
+		t.Errorf("SampleLines(ErrReader) returned error %v, want %v", err, errTest)
+	}
+}
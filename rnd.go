@@ -14,40 +14,139 @@
 //
 // This package works around that by using a concurrency safe and properly
 // seeded shared source and not allowing to seed it manually.
+//
+// By default, the package uses ChaCha8 to generate random numbers. A program
+// can trade robustness for speed, by building with the tag rnd_pcg (to use
+// PCG) or rnd_xoshiro256 (to use xoshiro256**). The generator is always
+// seeded by the package.
+//
+// None of the backends are cryptographically secure, so values generated by
+// this package must not be used where they must not be guessable, like keys,
+// session tokens or passwords. Use package gonih.org/rnd/secure for those.
 package rnd
 
 import (
+	"encoding/binary"
 	"hash/maphash"
 	"math"
-	"sync/atomic"
-
-	"golang.org/x/exp/rand"
+	"math/bits"
+	"math/rand/v2"
+	"sync"
+	"time"
 )
 
 var (
-	global = rand.New(new(rand.LockedSource))
-	// calls counts the approximate number of calls to Source.Uint64, for
-	// re-seeding occasionally.
-	calls uint64
+	src    = newLockedSource()
+	global = rand.New(src)
+	// calls counts the approximate number of calls to Source.Uint64 since the
+	// last re-seed, for re-seeding occasionally. It is sharded, as it is
+	// updated on every call.
+	calls shardedCounter
 )
 
-func init() {
-	global.Seed(new(maphash.Hash).Sum64())
+// lockedSource is a concurrency safe rand.Source.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+	// rec is set while recording. It then wraps the actual source and src is
+	// set to rec.
+	rec *recorder
+}
+
+func newLockedSource() *lockedSource {
+	return &lockedSource{src: newSource(defaultBackend, newSeed())}
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
 }
 
+// read fills p with random bytes, holding the lock only once.
+func (s *lockedSource) read(p []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	read(p, s.src)
+}
+
+// uint128 returns two values, holding the lock only once.
+func (s *lockedSource) uint128() (hi, lo uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64(), s.src.Uint64()
+}
+
+func (s *lockedSource) seed(seed [32]byte) {
+	s.set(newSource(defaultBackend, seed))
+}
+
+func (s *lockedSource) set(src rand.Source) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rec != nil {
+		s.rec.src = src
+		return
+	}
+	s.src = src
+}
+
+// newSeed returns a new random seed. It uses hash/maphash, which is seeded
+// by the runtime's source of randomness.
+func newSeed() (seed [32]byte) {
+	for i := 0; i < len(seed); i += 8 {
+		binary.LittleEndian.PutUint64(seed[i:], new(maphash.Hash).Sum64())
+	}
+	return seed
+}
+
+// read fills p with random bytes from src.
+func read(p []byte, src rand.Source) {
+	for len(p) >= 8 {
+		binary.LittleEndian.PutUint64(p, src.Uint64())
+		p = p[8:]
+	}
+	if len(p) > 0 {
+		v := src.Uint64()
+		for i := range p {
+			p[i] = byte(v)
+			v >>= 8
+		}
+	}
+}
+
+// reseedShard is the number of calls counted by a shard of calls, which
+// triggers a re-seed. As shards are chosen randomly, that happens after about
+// 2³² calls in total.
+const reseedShard = math.MaxUint32 / counterShards
+
 // reseed increments calls by n and perhaps re-seeds the global source.
 func reseed(n int) {
-	if atomic.AddUint64(&calls, uint64(n)) > math.MaxUint32 {
-		// Concurrent calls might run into this branch. That's fine, re-seeding
-		// happens very infrequently and isn't that expensive anyways.
-		global.Seed(new(maphash.Hash).Sum64())
+	i := shard()
+	c := calls.add(i, uint64(n))
+	if c <= reseedShard || debugSeeded.Load() || customSource.Load() {
+		return
 	}
+	// Only the goroutine resetting the shard re-seeds. If the swap fails,
+	// the next call will try again.
+	if calls.shards[i].n.CompareAndSwap(c, 0) {
+		stats.retired.Add(c + calls.reset())
+		rekey(ReasonCount)
+	}
+}
+
+// rekey re-seeds the global source and notifies the OnReseed hooks.
+func rekey(reason Reason) {
+	src.seed(newSeed())
+	stats.reseeds.Add(1)
+	stats.lastReseed.Store(time.Now().UnixNano())
+	notifyReseed(reason)
 }
 
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func Int63() int64 {
 	defer reseed(1)
-	return global.Int63()
+	return global.Int64()
 }
 
 // Uint32 returns a pseudo-random 32-bit value as a uint32.
@@ -62,10 +161,16 @@ func Uint64() uint64 {
 	return global.Uint64()
 }
 
+// Uint128 returns a pseudo-random 128-bit value, as its high and low 64 bits.
+func Uint128() (hi, lo uint64) {
+	defer reseed(2)
+	return src.uint128()
+}
+
 // Int31 returns a non-negative pseudo-random 31-bit integer as an int32.
 func Int31() int32 {
 	defer reseed(1)
-	return global.Int31()
+	return global.Int32()
 }
 
 // Int returns a non-negative pseudo-random int.
@@ -78,21 +183,28 @@ func Int() int {
 // It panics if n <= 0.
 func Int63n(n int64) int64 {
 	defer reseed(1)
-	return global.Int63n(n)
+	return global.Int64N(n)
+}
+
+// Uint64n returns, as a uint64, a pseudo-random number in [0,n).
+// It panics if n == 0.
+func Uint64n(n uint64) uint64 {
+	defer reseed(1)
+	return global.Uint64N(n)
 }
 
 // Int31n returns, as an int32, a non-negative pseudo-random number in [0,n).
 // It panics if n <= 0.
 func Int31n(n int32) int32 {
 	defer reseed(1)
-	return global.Int31n(n)
+	return global.Int32N(n)
 }
 
 // Intn returns, as an int, a non-negative pseudo-random number in [0,n).
 // It panics if n <= 0.
 func Intn(n int) int {
 	defer reseed(1)
-	return global.Intn(n)
+	return global.IntN(n)
 }
 
 // Float64 returns, as a float64, a pseudo-random number in [0.0,1.0).
@@ -121,11 +233,74 @@ func Shuffle[T any](s []T) {
 	reseed(len(s))
 }
 
+// ShuffleFunc pseudo-randomizes the order of elements. n is the number of
+// elements. ShuffleFunc panics if n < 0. swap swaps the elements with
+// indexes i and j.
+func ShuffleFunc(n int, swap func(i, j int)) {
+	defer reseed(n)
+	global.Shuffle(n, swap)
+}
+
 // Read generates len(p) random bytes and writes them into p. It always returns
 // len(p) and a nil error.
 func Read(p []byte) (n int, err error) {
 	defer reseed(len(p) / 8)
-	return global.Read(p)
+	src.read(p)
+	return len(p), nil
+}
+
+// Bytes returns n random bytes. It panics if n < 0.
+func Bytes(n int) []byte {
+	if n < 0 {
+		panic("invalid argument to Bytes")
+	}
+	p := make([]byte, n)
+	Read(p)
+	return p
+}
+
+// Ints fills dst with independent pseudo-random numbers in [0,n), like
+// calling Intn for every element. It synchronizes only once, so it is faster
+// than repeatedly calling Intn. It panics if n <= 0.
+func Ints(dst []int, n int) {
+	if n <= 0 {
+		panic("invalid argument to Ints")
+	}
+	defer reseed(len(dst))
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	for i := range dst {
+		dst[i] = int(uint64n(src.src, uint64(n)))
+	}
+}
+
+// Uint64ns fills dst with independent pseudo-random numbers in [0,n), like
+// calling Uint64n for every element. It synchronizes only once, so it is
+// faster than repeatedly calling Uint64n. It panics if n == 0.
+func Uint64ns(dst []uint64, n uint64) {
+	if n == 0 {
+		panic("invalid argument to Uint64ns")
+	}
+	defer reseed(len(dst))
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	for i := range dst {
+		dst[i] = uint64n(src.src, n)
+	}
+}
+
+// uint64n returns a uniform value in [0,n), using Lemire's multiply-and-shift
+// reduction. Values which would introduce bias are rejected, which is rare
+// unless n is close to 2⁶⁴.
+func uint64n(s rand.Source, n uint64) uint64 {
+	hi, lo := bits.Mul64(s.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(s.Uint64(), n)
+		}
+	}
+	return hi
 }
 
 // NormFloat64 returns a normally distributed float64 in the range
@@ -138,7 +313,28 @@ func Read(p []byte) (n int, err error) {
 //
 func NormFloat64() float64 {
 	defer reseed(1)
-	return global.NormFloat64()
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	return zigNorm(src.src)
+}
+
+// NormPair returns two independent, normally distributed float64s with
+// standard normal distribution (mean = 0, stddev = 1). It uses the Box–Muller
+// transform and synchronizes only once, so it is cheaper than calling
+// NormFloat64 twice.
+func NormPair() (float64, float64) {
+	defer reseed(2)
+	return boxMuller(src.uint128())
+}
+
+// boxMuller returns two independent standard normal values, computed from
+// two uniform 64-bit values using the Box–Muller transform.
+func boxMuller(a, b uint64) (float64, float64) {
+	u := float64(a>>11+1) / (1 << 53) // (0,1]
+	v := float64(b>>11) / (1 << 53)   // [0,1)
+	r := math.Sqrt(-2 * math.Log(u))
+	sin, cos := math.Sincos(2 * math.Pi * v)
+	return r * cos, r * sin
 }
 
 // ExpFloat64 returns an exponentially distributed float64 in the range
@@ -151,5 +347,7 @@ func NormFloat64() float64 {
 //
 func ExpFloat64() float64 {
 	defer reseed(1)
-	return global.ExpFloat64()
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	return zigExp(src.src)
 }
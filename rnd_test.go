@@ -4,16 +4,20 @@ import "testing"
 
 func Test(t *testing.T) {
 	// We can't test a lot, as the behavior of the package is intentionally
-	// non-deterministic. Also, the package only thinly wraps x/exp/rand
+	// non-deterministic. Also, the package only thinly wraps math/rand/v2
 	// anyways. But we can at least test that we can call every function,
 	// without panics.
 
 	Int63()
 	Uint32()
 	Uint64()
+	if hi, lo := Uint128(); hi == 0 && lo == 0 {
+		t.Error("Uint128() = 0, 0")
+	}
 	Int31()
 	Int()
 	Int63n(420)
+	Uint64n(420)
 	Int31n(420)
 	Intn(420)
 	Float64()
@@ -21,6 +25,7 @@ func Test(t *testing.T) {
 	Perm(420)
 	Shuffle[int](nil)
 	Shuffle(make([]int, 420))
+	ShuffleFunc(420, func(i, j int) {})
 	type myIntSlice []int
 	Shuffle(make(myIntSlice, 420))
 	if n, err := Read(nil); n != 0 || err != nil {
@@ -29,6 +34,47 @@ func Test(t *testing.T) {
 	if n, err := Read(make([]byte, 420)); n != 420 || err != nil {
 		t.Errorf("Read(<nil>) = %d, %v, want 420, <nil>", n, err)
 	}
+	if b := Bytes(420); len(b) != 420 {
+		t.Errorf("len(Bytes(420)) = %d, want 420", len(b))
+	}
 	NormFloat64()
 	ExpFloat64()
 }
+
+func TestInts(t *testing.T) {
+	const n = 60000
+	ints := make([]int, n)
+	Ints(ints, 6)
+	var counts [6]int
+	for _, v := range ints {
+		if v < 0 || v >= 6 {
+			t.Fatalf("Ints(dst, 6) returned %d", v)
+		}
+		counts[v]++
+	}
+	for v, c := range counts {
+		if c < 9500 || c > 10500 {
+			t.Errorf("Ints(dst, 6) returned %d %d times, want about 10000", v, c)
+		}
+	}
+	// A bound just above 2⁶³ rejects almost half of the candidates.
+	u := make([]uint64, 1000)
+	Uint64ns(u, 1<<63+1)
+	for _, v := range u {
+		if v > 1<<63 {
+			t.Fatalf("Uint64ns(dst, 1<<63+1) returned %d", v)
+		}
+	}
+	Uint64ns(u, 1)
+	for _, v := range u {
+		if v != 0 {
+			t.Fatalf("Uint64ns(dst, 1) returned %d", v)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Ints(dst, 0) did not panic")
+		}
+	}()
+	Ints(ints, 0)
+}
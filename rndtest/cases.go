@@ -0,0 +1,25 @@
+package rndtest
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ShuffleCases runs run for every element of cases as a subtest of t, in a
+// random order. Running table-driven tests in random order uncovers cases
+// which accidentally depend on state left behind by earlier ones.
+//
+// The order is seeded like the generators returned by New and the seed is
+// logged, so it can be replayed with -rndtest.seed. Subtests are named by the
+// index of their case in cases, so they can still be selected with -run. cases
+// is not modified.
+func ShuffleCases[C any](t *testing.T, cases []C, run func(t *testing.T, c C)) {
+	t.Helper()
+	seed, how := testSeed(t)
+	t.Logf("rndtest: running cases in order of seed %d (%s; reproduce with -rndtest.seed=%[1]d)", seed, how)
+	for _, i := range fromSeed(seed).Perm(len(cases)) {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			run(t, cases[i])
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Package rndtest provides deterministic random numbers for tests.
+//
+// Package rnd intentionally does not allow seeding its global source. Code
+// which wants to be tested deterministically should instead accept an
+// rnd.Generator (or an *rnd.Rand), using rnd.Global in production and a
+// generator returned by Fixed in tests.
+//
+// For convenience, this package also provides the same function set as
+// package rnd, backed by a shared generator with a fixed seed. Note that the
+// sequence observed by a test then depends on other users of the shared
+// generator, so using Fixed should be preferred.
+package rndtest
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"gonih.org/rnd"
+)
+
+// seed is the seed used by Fixed and the package-level functions.
+var seed = [32]byte([]byte("gonih.org/rnd/rndtest fixed seed"))
+
+// Fixed returns a new generator, which always produces the same stream.
+func Fixed() *rnd.Rand {
+	return rnd.FromSource(rand.NewChaCha8(seed))
+}
+
+var (
+	mu     sync.Mutex
+	global = Fixed()
+)
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func Int63() int64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Int63()
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+func Uint32() uint32 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Uint32()
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+func Uint64() uint64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Uint64()
+}
+
+// Int31 returns a non-negative pseudo-random 31-bit integer as an int32.
+func Int31() int32 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Int31()
+}
+
+// Int returns a non-negative pseudo-random int.
+func Int() int {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Int()
+}
+
+// Int63n returns, as an int64, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func Int63n(n int64) int64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Int63n(n)
+}
+
+// Uint64n returns, as a uint64, a pseudo-random number in [0,n).
+// It panics if n == 0.
+func Uint64n(n uint64) uint64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Uint64n(n)
+}
+
+// Int31n returns, as an int32, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func Int31n(n int32) int32 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Int31n(n)
+}
+
+// Intn returns, as an int, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func Intn(n int) int {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Intn(n)
+}
+
+// Float64 returns, as a float64, a pseudo-random number in [0.0,1.0).
+func Float64() float64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Float64()
+}
+
+// Float32 returns, as a float32, a pseudo-random number in [0.0,1.0).
+func Float32() float32 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Float32()
+}
+
+// Perm returns, as a slice of n ints, a pseudo-random permutation of the integers [0,n).
+func Perm(n int) []int {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of elements of s.
+func Shuffle[T any](s []T) {
+	mu.Lock()
+	defer mu.Unlock()
+	global.Shuffle(len(s), func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
+
+// ShuffleFunc pseudo-randomizes the order of elements. n is the number of
+// elements. ShuffleFunc panics if n < 0. swap swaps the elements with
+// indexes i and j.
+func ShuffleFunc(n int, swap func(i, j int)) {
+	mu.Lock()
+	defer mu.Unlock()
+	global.Shuffle(n, swap)
+}
+
+// Read generates len(p) random bytes and writes them into p. It always returns
+// len(p) and a nil error.
+func Read(p []byte) (n int, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.Read(p)
+}
+
+// NormFloat64 returns a normally distributed float64 in the range
+// [-math.MaxFloat64, +math.MaxFloat64] with
+// standard normal distribution (mean = 0, stddev = 1).
+func NormFloat64() float64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed float64 in the range
+// (0, +math.MaxFloat64] with an exponential distribution whose rate parameter
+// (lambda) is 1 and whose mean is 1/lambda (1).
+func ExpFloat64() float64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return global.ExpFloat64()
+}
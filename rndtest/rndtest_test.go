@@ -0,0 +1,107 @@
+package rndtest
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"gonih.org/rnd"
+)
+
+func TestFixed(t *testing.T) {
+	var a, b rnd.Generator = Fixed(), Fixed()
+	for i := 0; i < 10; i++ {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("Fixed returned different streams: %d != %d", x, y)
+		}
+	}
+}
+
+func Test(t *testing.T) {
+	// Like for package rnd, we only test that we can call every function,
+	// without panics.
+	Int63()
+	Uint32()
+	Uint64()
+	Int31()
+	Int()
+	Int63n(420)
+	Uint64n(420)
+	Int31n(420)
+	Intn(420)
+	Float64()
+	Float32()
+	Perm(420)
+	Shuffle(make([]int, 420))
+	ShuffleFunc(420, func(i, j int) {})
+	if n, err := Read(make([]byte, 420)); n != 420 || err != nil {
+		t.Errorf("Read(<nil>) = %d, %v, want 420, <nil>", n, err)
+	}
+	NormFloat64()
+	ExpFloat64()
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv(seedEnv, "42")
+	a, b := New(t), New(t)
+	for i := 0; i < 10; i++ {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("New returned different streams for the same seed: %d != %d", x, y)
+		}
+	}
+}
+
+func TestQuickRand(t *testing.T) {
+	t.Setenv(seedEnv, "42")
+	a, b := QuickRand(t), QuickRand(t)
+	if x, y := a.Int63(), b.Int63(); x != y {
+		t.Fatalf("QuickRand returned different streams for the same seed: %d != %d", x, y)
+	}
+	cfg := &quick.Config{Rand: QuickRand(t)}
+	if err := quick.Check(func(x int) bool { return x == x }, cfg); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestShuffleCases(t *testing.T) {
+	t.Setenv(seedEnv, "42")
+	cases := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	order := func() []string {
+		var got []string
+		ShuffleCases(t, cases, func(t *testing.T, c string) {
+			got = append(got, c)
+		})
+		return got
+	}
+	a, b := order(), order()
+	if !slices.Equal(a, b) {
+		t.Errorf("ShuffleCases used different orders for the same seed: %v != %v", a, b)
+	}
+	slices.Sort(a)
+	if !slices.Equal(a, cases) {
+		t.Errorf("ShuffleCases ran cases %v, want %v", a, cases)
+	}
+}
+
+type constSource uint64
+
+func (s constSource) Uint64() uint64 { return uint64(s) }
+
+func TestSetSource(t *testing.T) {
+	rnd.Uint64()
+	t.Run("sub", func(t *testing.T) {
+		SetSource(t, constSource(42))
+		if v := rnd.Uint64(); v != 42 {
+			t.Fatalf("rnd.Uint64() = %d with constant source, want 42", v)
+		}
+	})
+	if rnd.Uint64() == 42 && rnd.Uint64() == 42 {
+		t.Fatal("default source not restored after the test")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("rnd.SetSource after use did not panic")
+		}
+	}()
+	rnd.SetSource(constSource(42))
+}
@@ -0,0 +1,81 @@
+package rndtest
+
+import (
+	"encoding/binary"
+	"flag"
+	randv1 "math/rand"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"testing"
+
+	"gonih.org/rnd"
+)
+
+// seedEnv is the environment variable overriding the seed used by New. The
+// flag -rndtest.seed takes precedence.
+const seedEnv = "RNDTEST_SEED"
+
+var seedFlag *uint64
+
+func init() {
+	flag.Func("rndtest.seed", "seed to use for generators returned by rndtest.New", func(s string) error {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		seedFlag = &v
+		return nil
+	})
+}
+
+// New returns a new generator for use in the test t. Unless overwritten, it is
+// seeded randomly and the seed is logged, so a failing test can be
+// reproduced.
+//
+// The seed can be overwritten by passing -rndtest.seed=<seed> to go test, or
+// by setting the environment variable RNDTEST_SEED=<seed>.
+func New(t testing.TB) *rnd.Rand {
+	t.Helper()
+	seed, how := testSeed(t)
+	t.Logf("rndtest: using seed %d (%s; reproduce with -rndtest.seed=%[1]d)", seed, how)
+	return fromSeed(seed)
+}
+
+// QuickRand returns a new *rand.Rand for use as quick.Config.Rand in the test
+// t. It is seeded like the generators returned by New, but the seed is only
+// logged if t fails.
+func QuickRand(t testing.TB) *randv1.Rand {
+	t.Helper()
+	seed, how := testSeed(t)
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("rndtest: testing/quick used seed %d (%s; reproduce with -rndtest.seed=%[1]d)", seed, how)
+		}
+	})
+	return randv1.New(randv1.NewSource(int64(seed)))
+}
+
+// testSeed returns the seed to use for a test and a description of where it
+// came from.
+func testSeed(t testing.TB) (seed uint64, how string) {
+	t.Helper()
+	if seedFlag != nil {
+		return *seedFlag, "from -rndtest.seed"
+	}
+	if s := os.Getenv(seedEnv); s != "" {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			t.Fatalf("rndtest: invalid %s: %v", seedEnv, err)
+		}
+		return v, "from $" + seedEnv
+	}
+	return rnd.Uint64(), "random"
+}
+
+// fromSeed returns a new generator seeded with seed.
+func fromSeed(seed uint64) *rnd.Rand {
+	var s [32]byte
+	binary.LittleEndian.PutUint64(s[:], seed)
+	return rnd.FromSource(rand.NewChaCha8(s))
+}
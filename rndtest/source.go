@@ -0,0 +1,25 @@
+package rndtest
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"gonih.org/rnd"
+	"gonih.org/rnd/internal/testhook"
+)
+
+// SetSource replaces the global source of package rnd by s, for the duration
+// of the test t. Unlike rnd.SetSource, it may be called after the global
+// source has been used. The default source is restored, when t finishes.
+//
+// Tests calling SetSource must not run in parallel with other tests using
+// the global source.
+func SetSource(t testing.TB, s rand.Source) {
+	t.Helper()
+	testhook.LateSetSource.Add(1)
+	t.Cleanup(func() {
+		rnd.SetSource(nil)
+		testhook.LateSetSource.Add(-1)
+	})
+	rnd.SetSource(s)
+}
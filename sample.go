@@ -0,0 +1,105 @@
+package rnd
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Sampler decides whether to sample events, with a fixed probability. It is
+// safe for concurrent use.
+//
+// Instead of drawing a random number for every event, Sampler precomputes the
+// number of events to skip until the next sampled one, which is
+// geometrically distributed. So for small rates, Sample usually only costs a
+// single atomic operation.
+type Sampler struct {
+	logq float64 // log(1-rate)
+	skip atomic.Int64
+	// never is set for a rate of 0.
+	never bool
+}
+
+// NewSampler returns a Sampler, sampling events with probability rate. It
+// panics if rate is not in [0,1].
+func NewSampler(rate float64) *Sampler {
+	if !(rate >= 0 && rate <= 1) {
+		panic("invalid rate for NewSampler")
+	}
+	s := &Sampler{logq: math.Log1p(-rate), never: rate == 0}
+	s.skip.Store(s.nextSkip())
+	return s
+}
+
+// nextSkip returns the number of events to skip before sampling the next one.
+func (s *Sampler) nextSkip() int64 {
+	if s.logq == 0 {
+		return 0
+	}
+	// 1-Float64() is in (0,1], so the logarithm is finite.
+	n := math.Floor(math.Log(1-Float64()) / s.logq)
+	if n >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(n)
+}
+
+// Sample returns whether the current event should be sampled.
+func (s *Sampler) Sample() bool {
+	if s.never {
+		return false
+	}
+	for {
+		v := s.skip.Load()
+		if v > 0 {
+			if s.skip.CompareAndSwap(v, v-1) {
+				return false
+			}
+			continue
+		}
+		if s.skip.CompareAndSwap(0, s.nextSkip()) {
+			return true
+		}
+	}
+}
+
+// OneIn returns true with probability 1/n. It panics if n <= 0.
+func OneIn(n int) bool {
+	if n <= 0 {
+		panic("invalid argument to OneIn")
+	}
+	return Intn(n) == 0
+}
+
+// KOutOfN returns true with probability exactly k/n. Unlike comparing
+// Float64 to a float64 probability, it uses only integer arithmetic, so the
+// probability is not subject to rounding. It panics if n == 0 or k > n.
+func KOutOfN(k, n uint64) bool {
+	if n == 0 || k > n {
+		panic("invalid argument to KOutOfN")
+	}
+	return Uint64n(n) < k
+}
+
+// CountingSampler samples exactly every n-th event, starting at a random
+// offset. Compared to a Sampler, it produces a predictable volume of sampled
+// events, while still randomizing which ones. It is safe for concurrent use.
+type CountingSampler struct {
+	n     uint64
+	count atomic.Uint64
+}
+
+// NewCountingSampler returns a CountingSampler, sampling one in n events. It
+// panics if n <= 0.
+func NewCountingSampler(n int) *CountingSampler {
+	if n <= 0 {
+		panic("invalid argument to NewCountingSampler")
+	}
+	s := &CountingSampler{n: uint64(n)}
+	s.count.Store(Uint64n(uint64(n)))
+	return s
+}
+
+// Sample returns whether the current event should be sampled.
+func (s *CountingSampler) Sample() bool {
+	return s.count.Add(1)%s.n == 0
+}
@@ -0,0 +1,95 @@
+package rnd
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSampler(t *testing.T) {
+	const n = 100000
+	for _, rate := range []float64{0, 0.001, 0.1, 0.5, 1} {
+		s := NewSampler(rate)
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			sampled int
+		)
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var c int
+				for i := 0; i < n/4; i++ {
+					if s.Sample() {
+						c++
+					}
+				}
+				mu.Lock()
+				sampled += c
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		// Allow for 5 standard deviations.
+		want := rate * n
+		if tol := 5 * math.Sqrt(n*rate*(1-rate)); math.Abs(float64(sampled)-want) > tol {
+			t.Errorf("NewSampler(%v) sampled %d of %d events, want %v±%v", rate, sampled, n, want, tol)
+		}
+	}
+}
+
+func TestOneIn(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if !OneIn(1) {
+			t.Fatal("OneIn(1) = false")
+		}
+	}
+	var c int
+	for i := 0; i < 10000; i++ {
+		if OneIn(10) {
+			c++
+		}
+	}
+	if c < 850 || c > 1150 {
+		t.Errorf("OneIn(10) returned true %d of 10000 times", c)
+	}
+}
+
+func TestKOutOfN(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if KOutOfN(0, 3) || !KOutOfN(3, 3) || !KOutOfN(1<<63, 1<<63) {
+			t.Fatal("KOutOfN(0, 3) or KOutOfN(n, n) returned wrong result")
+		}
+	}
+	var c int
+	for i := 0; i < 10000; i++ {
+		if KOutOfN(3, 10) {
+			c++
+		}
+	}
+	if c < 2800 || c > 3200 {
+		t.Errorf("KOutOfN(3, 10) returned true %d of 10000 times", c)
+	}
+}
+
+func TestCountingSampler(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 100} {
+		s := NewCountingSampler(n)
+		first, c := -1, 0
+		for i := 0; i < 10*n; i++ {
+			if s.Sample() {
+				if first < 0 {
+					first = i
+				}
+				if (i-first)%n != 0 {
+					t.Fatalf("NewCountingSampler(%d) sampled events %d and %d", n, first, i)
+				}
+				c++
+			}
+		}
+		if c != 10 {
+			t.Errorf("NewCountingSampler(%d) sampled %d of %d events, want 10", n, c, 10*n)
+		}
+	}
+}
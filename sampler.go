@@ -0,0 +1,224 @@
+package rnd
+
+import "math"
+
+// The samplers in this file do their setup once, when they are created, and
+// are immutable afterwards, so they are safe for concurrent use without
+// locking. Their Next methods draw from a pooled generator, as WithRand does,
+// so they do not contend on the global source either.
+
+// AliasTable samples indices with probability proportional to a fixed set of
+// weights, in constant time per sample. It is safe for concurrent use.
+type AliasTable struct {
+	// prob[i] is the probability of returning i, once column i is chosen.
+	// Otherwise, alias[i] is returned.
+	prob  []float64
+	alias []int
+}
+
+// NewAliasTable returns an AliasTable for weights, using Vose's alias method.
+// Building the table takes O(len(weights)) time. weights is not retained.
+//
+// For weights which change over time, use DynamicWeighted instead.
+//
+// NewAliasTable panics if any weight is negative, NaN or infinite, or all
+// weights are 0.
+func NewAliasTable(weights []float64) *AliasTable {
+	n := len(weights)
+	var total float64
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid weight for NewAliasTable")
+		}
+		total += w
+	}
+	if !(total > 0) {
+		panic("zero total weight for NewAliasTable")
+	}
+	t := &AliasTable{
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	var small, large []int
+	for i, w := range weights {
+		t.prob[i] = w * float64(n) / total
+		if t.prob[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		t.alias[s] = l
+		t.prob[l] -= 1 - t.prob[s]
+		if t.prob[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// Due to rounding errors, the remaining columns might have probabilities
+	// slightly different from 1.
+	for _, i := range large {
+		t.prob[i] = 1
+	}
+	for _, i := range small {
+		t.prob[i] = 1
+	}
+	return t
+}
+
+// Len returns the number of weights of t.
+func (t *AliasTable) Len() int {
+	return len(t.prob)
+}
+
+// Next returns a pseudo-random index into the weights of t.
+func (t *AliasTable) Next() int {
+	var i int
+	WithRand(func(r *Rand) { i = t.Sample(r) })
+	return i
+}
+
+// Sample returns an index into the weights of t, using g as the source of
+// randomness.
+func (t *AliasTable) Sample(g Generator) int {
+	i := g.Intn(len(t.prob))
+	if g.Float64() < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
+
+// Zipf generates Zipf distributed values in [0,imax]. The probability of k is
+// proportional to (v+k)^-s. It is safe for concurrent use.
+type Zipf struct {
+	imax        float64
+	v           float64
+	q           float64
+	s           float64
+	oneMinusQ   float64
+	oneMinusQi  float64
+	hxm         float64
+	hx0MinusHxm float64
+}
+
+// NewZipf returns a Zipf distribution with the given parameters, like
+// math/rand.NewZipf. It uses the rejection-inversion method of Hörmann and
+// Derflinger, whose constants are computed once, so sampling takes expected
+// constant time. It panics if s <= 1 or v < 1.
+func NewZipf(s, v float64, imax uint64) *Zipf {
+	if !(s > 1) || !(v >= 1) {
+		panic("invalid argument to NewZipf")
+	}
+	z := &Zipf{
+		imax:       float64(imax),
+		v:          v,
+		q:          s,
+		oneMinusQ:  1 - s,
+		oneMinusQi: 1 / (1 - s),
+	}
+	z.hxm = z.h(z.imax + 0.5)
+	z.hx0MinusHxm = z.h(0.5) - math.Exp(math.Log(z.v)*(-z.q)) - z.hxm
+	z.s = 1 - z.hinv(z.h(1.5)-math.Exp(-z.q*math.Log(z.v+1)))
+	return z
+}
+
+func (z *Zipf) h(x float64) float64 {
+	return math.Exp(z.oneMinusQ*math.Log(z.v+x)) * z.oneMinusQi
+}
+
+func (z *Zipf) hinv(x float64) float64 {
+	return math.Exp(z.oneMinusQi*math.Log(z.oneMinusQ*x)) - z.v
+}
+
+// Next returns a pseudo-random value drawn from z.
+func (z *Zipf) Next() uint64 {
+	var k uint64
+	WithRand(func(r *Rand) { k = z.Sample(r) })
+	return k
+}
+
+// Sample returns a value drawn from z, using g as the source of randomness.
+func (z *Zipf) Sample(g Generator) uint64 {
+	for {
+		ur := z.hxm + g.Float64()*z.hx0MinusHxm
+		x := z.hinv(ur)
+		k := math.Floor(x + 0.5)
+		if k-x <= z.s || ur >= z.h(k+0.5)-math.Exp(-math.Log(k+z.v)*z.q) {
+			return uint64(min(k, z.imax))
+		}
+	}
+}
+
+// binomialMaxTable is the maximum size of the table of a Binomial.
+const binomialMaxTable = 1 << 20
+
+// Binomial generates binomially distributed values, that is the number of
+// successes in n independent trials, each succeeding with probability p. It is
+// safe for concurrent use.
+type Binomial struct {
+	lo int
+	t  *AliasTable
+	// mu and sigma are used instead of the table, if the distribution is too
+	// wide for one.
+	mu, sigma float64
+	n         int
+}
+
+// NewBinomial returns the binomial distribution with parameters n and p.
+//
+// It tabulates the probabilities of all values within 10 standard deviations
+// of the mean, which contain all but a negligible fraction of the mass, so
+// sampling takes constant time. If that range would contain more than 2²⁰
+// values, sampling uses the normal approximation instead, rounded to the
+// nearest integer in [0,n], which is accurate at that size.
+//
+// NewBinomial panics if n < 0 or p is not in [0,1].
+func NewBinomial(n int, p float64) *Binomial {
+	if n < 0 || !(p >= 0 && p <= 1) {
+		panic("invalid argument to NewBinomial")
+	}
+	mu := float64(n) * p
+	sigma := math.Sqrt(mu * (1 - p))
+	lo := max(0, int(math.Floor(mu-10*sigma)))
+	hi := min(n, int(math.Ceil(mu+10*sigma)))
+	b := &Binomial{lo: lo, mu: mu, sigma: sigma, n: n}
+	if hi-lo+1 > binomialMaxTable {
+		return b
+	}
+	if p == 0 || p == 1 {
+		b.t = NewAliasTable([]float64{1})
+		return b
+	}
+	// Compute the probabilities relative to the mode, to avoid underflow.
+	logPMF := func(k int) float64 {
+		lk, _ := math.Lgamma(float64(k + 1))
+		lnk, _ := math.Lgamma(float64(n - k + 1))
+		return -lk - lnk + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p)
+	}
+	mode := logPMF(min(n, int(float64(n+1)*p)))
+	w := make([]float64, hi-lo+1)
+	for i := range w {
+		w[i] = math.Exp(logPMF(lo+i) - mode)
+	}
+	b.t = NewAliasTable(w)
+	return b
+}
+
+// Next returns a pseudo-random value drawn from b.
+func (b *Binomial) Next() int {
+	var k int
+	WithRand(func(r *Rand) { k = b.Sample(r) })
+	return k
+}
+
+// Sample returns a value drawn from b, using g as the source of randomness.
+func (b *Binomial) Sample(g Generator) int {
+	if b.t != nil {
+		return b.lo + b.t.Sample(g)
+	}
+	k := math.Round(b.mu + b.sigma*g.NormFloat64())
+	return int(max(0, min(float64(b.n), k)))
+}
@@ -0,0 +1,126 @@
+package rnd
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestAliasTable(t *testing.T) {
+	const n = 100000
+	weights := []float64{1, 0, 3, 6}
+	tab := NewAliasTable(weights)
+	if tab.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", tab.Len())
+	}
+	var counts [4]int
+	for range n {
+		counts[tab.Next()]++
+	}
+	for i, w := range weights {
+		if got := float64(counts[i]) / n; math.Abs(got-w/10) > 0.01 {
+			t.Errorf("index %d was returned with probability %v, want %v", i, got, w/10)
+		}
+	}
+	for _, w := range [][]float64{nil, {0, 0}, {1, -1}, {math.NaN()}, {1, math.Inf(1)}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewAliasTable(%v) did not panic", w)
+				}
+			}()
+			NewAliasTable(w)
+		}()
+	}
+}
+
+func TestZipf(t *testing.T) {
+	const (
+		n    = 100000
+		s    = 2.0
+		imax = 100
+	)
+	z := NewZipf(s, 1, imax)
+	var norm float64
+	for k := 0; k <= imax; k++ {
+		norm += math.Pow(1+float64(k), -s)
+	}
+	var counts [imax + 1]int
+	for range n {
+		k := z.Next()
+		if k > imax {
+			t.Fatalf("Next() = %d, want at most %d", k, imax)
+		}
+		counts[k]++
+	}
+	for k := range 3 {
+		want := math.Pow(1+float64(k), -s) / norm
+		if got := float64(counts[k]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("%d was returned with probability %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	const n = 100000
+	for _, tc := range []struct {
+		n int
+		p float64
+	}{
+		{10, 0.5},
+		{1000, 0.01},
+		{1 << 50, 0.25},
+		{7, 0},
+		{7, 1},
+	} {
+		b := NewBinomial(tc.n, tc.p)
+		wantMean := float64(tc.n) * tc.p
+		wantVar := wantMean * (1 - tc.p)
+		// Accumulate deviations from the expected mean, to avoid losing
+		// precision for large n.
+		var sum, sum2 float64
+		for range n {
+			k := b.Next()
+			if k < 0 || k > tc.n {
+				t.Fatalf("NewBinomial(%d, %v).Next() = %d, want in [0,%d]", tc.n, tc.p, k, tc.n)
+			}
+			d := float64(k) - wantMean
+			sum += d
+			sum2 += d * d
+		}
+		if d := sum / n; math.Abs(d) > 5*math.Sqrt(wantVar/n) {
+			t.Errorf("NewBinomial(%d, %v) has mean %v, want %v", tc.n, tc.p, wantMean+d, wantMean)
+		}
+		if v := sum2 / n; wantVar > 0 && math.Abs(v/wantVar-1) > 0.05 {
+			t.Errorf("NewBinomial(%d, %v) has variance %v, want %v", tc.n, tc.p, v, wantVar)
+		}
+	}
+}
+
+func TestSamplersConcurrent(t *testing.T) {
+	tab := NewAliasTable([]float64{1, 2, 3})
+	z := NewZipf(1.5, 2, 1000)
+	b := NewBinomial(100, 0.3)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				tab.Next()
+				z.Next()
+				b.Next()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkZipf(b *testing.B) {
+	z := NewZipf(1.1, 1, 1<<20)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			z.Next()
+		}
+	})
+}
@@ -0,0 +1,255 @@
+// Package secure provides unpredictable random values, with the API of
+// package gonih.org/rnd.
+//
+// The functions of this package are backed by a ChaCha8 generator in user
+// space, which is re-keyed from crypto/rand regularly. ChaCha8 is a
+// cryptographically secure generator, so unlike the values of package
+// gonih.org/rnd, the values of this package can be used for secrets, like
+// session tokens, or for decisions that must not be predictable, like
+// lotteries. As the generator runs in user space, it is much faster than
+// reading from crypto/rand for every value.
+//
+// Unlike package gonih.org/rnd, this package can not be seeded
+// deterministically. All functions are safe for concurrent use.
+package secure
+
+import (
+	crand "crypto/rand"
+	"encoding/base64"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"strings"
+	"sync"
+)
+
+// rekeyAfter is the number of 64-bit values generated by the source before it
+// is re-keyed from crypto/rand.
+const rekeyAfter = 1 << 16
+
+// source is a rand.Source using ChaCha8, periodically re-keyed from
+// crypto/rand. It is safe for concurrent use.
+type source struct {
+	mu sync.Mutex
+	c  *rand.ChaCha8
+	n  int
+}
+
+func (s *source) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.use(1)
+	return s.c.Uint64()
+}
+
+func (s *source) read(p []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.use((len(p) + 7) / 8)
+	s.c.Read(p)
+}
+
+// use accounts for n values about to be generated and re-keys s if needed. It
+// must be called with s.mu held.
+func (s *source) use(n int) {
+	if s.c != nil && s.n < rekeyAfter {
+		s.n += n
+		return
+	}
+	var seed [32]byte
+	if _, err := crand.Read(seed[:]); err != nil {
+		panic("secure: reading from crypto/rand failed: " + err.Error())
+	}
+	s.c, s.n = rand.NewChaCha8(seed), n
+}
+
+var (
+	src    = new(source)
+	global = rand.New(src)
+)
+
+// Int63 returns a non-negative random 63-bit integer as an int64.
+func Int63() int64 {
+	return global.Int64()
+}
+
+// Uint32 returns a random 32-bit value as a uint32.
+func Uint32() uint32 {
+	return global.Uint32()
+}
+
+// Uint64 returns a random 64-bit value as a uint64.
+func Uint64() uint64 {
+	return global.Uint64()
+}
+
+// Int31 returns a non-negative random 31-bit integer as an int32.
+func Int31() int32 {
+	return global.Int32()
+}
+
+// Int returns a non-negative random int.
+func Int() int {
+	return global.Int()
+}
+
+// Int63n returns, as an int64, a non-negative random number in the half-open
+// interval [0,n). It panics if n <= 0.
+func Int63n(n int64) int64 {
+	if n <= 0 {
+		panic("invalid argument to Int63n")
+	}
+	return global.Int64N(n)
+}
+
+// Uint64n returns, as a uint64, a random number in the half-open interval
+// [0,n). It panics if n == 0.
+func Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	return global.Uint64N(n)
+}
+
+// Int31n returns, as an int32, a non-negative random number in the half-open
+// interval [0,n). It panics if n <= 0.
+func Int31n(n int32) int32 {
+	if n <= 0 {
+		panic("invalid argument to Int31n")
+	}
+	return global.Int32N(n)
+}
+
+// Intn returns, as an int, a non-negative random number in the half-open
+// interval [0,n). It panics if n <= 0.
+func Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return global.IntN(n)
+}
+
+// Float64 returns, as a float64, a random number in the half-open interval
+// [0.0,1.0).
+func Float64() float64 {
+	return global.Float64()
+}
+
+// Float32 returns, as a float32, a random number in the half-open interval
+// [0.0,1.0).
+func Float32() float32 {
+	return global.Float32()
+}
+
+// NormFloat64 returns a normally distributed float64 with standard normal
+// distribution (mean = 0, stddev = 1).
+func NormFloat64() float64 {
+	return global.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate parameter
+// 1.
+func ExpFloat64() float64 {
+	return global.ExpFloat64()
+}
+
+// Perm returns, as a slice of n ints, a random permutation of the integers
+// [0,n).
+func Perm(n int) []int {
+	return global.Perm(n)
+}
+
+// Shuffle randomizes the order of elements of s.
+func Shuffle[T any](s []T) {
+	global.Shuffle(len(s), func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
+
+// ShuffleFunc randomizes the order of elements. n is the number of elements.
+// ShuffleFunc panics if n < 0. swap swaps the elements with indexes i and j.
+func ShuffleFunc(n int, swap func(i, j int)) {
+	global.Shuffle(n, swap)
+}
+
+// Pick returns a random element of s. It panics if s is empty.
+func Pick[T any](s []T) T {
+	if len(s) == 0 {
+		panic("empty slice passed to Pick")
+	}
+	return s[global.IntN(len(s))]
+}
+
+// Read generates len(p) random bytes and writes them into p. It always returns
+// len(p) and a nil error.
+func Read(p []byte) (n int, err error) {
+	src.read(p)
+	return len(p), nil
+}
+
+// Bytes returns n random bytes. It panics if n < 0.
+func Bytes(n int) []byte {
+	if n < 0 {
+		panic("invalid argument to Bytes")
+	}
+	p := make([]byte, n)
+	Read(p)
+	return p
+}
+
+// Token returns nBytes random bytes, encoded as unpadded URL-safe base64
+// (RFC 4648). With nBytes ≥ 16, it is suitable as a session token or API
+// key.
+func Token(nBytes int) string {
+	return base64.RawURLEncoding.EncodeToString(Bytes(nBytes))
+}
+
+// base62 is the alphabet used by Base62Token.
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Base62Token returns a random string of alphanumeric characters, containing
+// at least as much randomness as nBytes random bytes.
+func Base62Token(nBytes int) string {
+	n := int(math.Ceil(float64(8*nBytes) / math.Log2(62)))
+	return ID(n, base62)
+}
+
+// ID returns a random identifier of the given length, consisting of bytes
+// from alphabet. Every byte of alphabet is chosen with the same probability.
+//
+// ID panics if alphabet is empty or longer than 256 bytes.
+func ID(length int, alphabet string) string {
+	if len(alphabet) == 0 || len(alphabet) > 256 {
+		panic("invalid alphabet for ID")
+	}
+	// To avoid modulo bias, we mask random bytes to the smallest power of two
+	// covering the alphabet and reject values outside of it.
+	mask := byte(1<<bits.Len(uint(len(alphabet)-1)) - 1)
+	b := make([]byte, length)
+	var buf [64]byte
+	for i := 0; i < length; {
+		p := buf[:min(len(buf), 2*(length-i))]
+		Read(p)
+		for _, c := range p {
+			if c &= mask; int(c) < len(alphabet) {
+				b[i] = alphabet[c]
+				if i++; i == length {
+					break
+				}
+			}
+		}
+	}
+	return string(b)
+}
+
+// HexString returns a string of n random hexadecimal (lower case) digits.
+func HexString(n int) string {
+	const digits = "0123456789abcdef"
+	var sb strings.Builder
+	sb.Grow(n)
+	for _, c := range Bytes((n + 1) / 2) {
+		sb.WriteByte(digits[c>>4])
+		sb.WriteByte(digits[c&0xf])
+	}
+	return sb.String()[:n]
+}
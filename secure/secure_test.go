@@ -0,0 +1,77 @@
+package secure
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test(t *testing.T) {
+	// Like for package rnd, we can't test a lot, but we can at least call
+	// every function.
+	Int63()
+	Uint32()
+	Uint64()
+	Int31()
+	Int()
+	Int63n(420)
+	Uint64n(420)
+	Int31n(420)
+	Intn(420)
+	Float64()
+	Float32()
+	NormFloat64()
+	ExpFloat64()
+	if p := Perm(420); len(p) != 420 {
+		t.Errorf("len(Perm(420)) = %d", len(p))
+	}
+	Shuffle[int](nil)
+	ShuffleFunc(420, func(i, j int) {})
+	if v := Pick([]int{42}); v != 42 {
+		t.Errorf("Pick([42]) = %d", v)
+	}
+	if n, err := Read(make([]byte, 420)); n != 420 || err != nil {
+		t.Errorf("Read(make([]byte, 420)) = %d, %v, want 420, <nil>", n, err)
+	}
+	if b := Bytes(420); len(b) != 420 {
+		t.Errorf("len(Bytes(420)) = %d, want 420", len(b))
+	}
+	if s := Token(16); len(s) != 22 {
+		t.Errorf("Token(16) = %q, want 22 characters", s)
+	}
+	if s := Base62Token(16); len(s) != 22 {
+		t.Errorf("Base62Token(16) = %q, want 22 characters", s)
+	}
+	if s := ID(20, "ab"); len(s) != 20 || strings.Trim(s, "ab") != "" {
+		t.Errorf(`ID(20, "ab") = %q`, s)
+	}
+	for n := range 10 {
+		if s := HexString(n); len(s) != n || strings.Trim(s, "0123456789abcdef") != "" {
+			t.Errorf("HexString(%d) = %q", n, s)
+		}
+	}
+}
+
+func TestRekey(t *testing.T) {
+	// Generate enough values to re-key a couple of times and check that the
+	// output still looks random.
+	seen := make(map[uint64]bool)
+	for range 3 * rekeyAfter {
+		v := Uint64()
+		if seen[v] {
+			t.Fatalf("Uint64 repeated %#x", v)
+		}
+		seen[v] = true
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i
+	}
+	Shuffle(s)
+	if slices.IsSorted(s) {
+		t.Error("Shuffle did not change the order")
+	}
+}
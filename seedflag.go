@@ -0,0 +1,79 @@
+package rnd
+
+import (
+	"encoding/binary"
+	"flag"
+	"strconv"
+	"sync"
+)
+
+// SeedFlag is a flag.Value for a -seed flag, so programs can make their use
+// of randomness reproducible. The flag accepts a token, as printed by
+// SeedToken.String, or an unsigned integer. If the flag is not set, a random
+// seed is chosen, which can be printed to reproduce the run later.
+//
+// The zero SeedFlag is ready to use. Its methods are safe for concurrent use.
+type SeedFlag struct {
+	mu  sync.Mutex
+	tok SeedToken
+	ok  bool
+}
+
+var _ flag.Value = (*SeedFlag)(nil)
+
+// NewSeedFlag defines a SeedFlag with the given name and usage on
+// flag.CommandLine.
+func NewSeedFlag(name, usage string) *SeedFlag {
+	f := new(SeedFlag)
+	flag.Var(f, name, usage)
+	return f
+}
+
+// String returns the token of the seed, or the empty string if no seed has
+// been set or chosen yet.
+func (f *SeedFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.ok {
+		return ""
+	}
+	return f.tok.String()
+}
+
+// Set sets the seed from s, which is either a seed token or an unsigned
+// integer. An integer is used as a seed for the backend of the global source,
+// so the stream for a given integer depends on build tags.
+func (f *SeedFlag) Set(s string) error {
+	var tok SeedToken
+	if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+		binary.LittleEndian.PutUint64(tok.Seed[:], v)
+		tok.Backend = defaultBackend
+	} else if tok, err = ParseSeedToken(s); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tok, f.ok = tok, true
+	return nil
+}
+
+// Token returns the token of the seed. If no seed has been set, a random one
+// is chosen and returned by all later calls, so a program can log it.
+func (f *SeedFlag) Token() SeedToken {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.ok {
+		f.tok, f.ok = NewSeedToken(), true
+	}
+	return f.tok
+}
+
+// Rand returns a new Rand, producing the stream identified by Token. Every
+// call returns a generator producing the same stream. Use Split or Derive
+// on it to create several independent generators.
+func (f *SeedFlag) Rand() *Rand {
+	return f.Token().Rand()
+}
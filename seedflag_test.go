@@ -0,0 +1,41 @@
+package rnd
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestSeedFlag(t *testing.T) {
+	var f SeedFlag
+	if s := f.String(); s != "" {
+		t.Errorf("String() = %q for unset flag, want empty", s)
+	}
+	tok := f.Token()
+	if f.String() != tok.String() || f.Token() != tok {
+		t.Errorf("the chosen seed is not recorded")
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var g SeedFlag
+	fs.Var(&g, "seed", "")
+	if err := fs.Parse([]string{"-seed", tok.String()}); err != nil {
+		t.Fatal(err)
+	}
+	if a, b := f.Rand().Uint64(), g.Rand().Uint64(); a != b {
+		t.Errorf("SeedFlag set to token of other flag produces %#x, want %#x", b, a)
+	}
+
+	var h, i SeedFlag
+	h.Set("42")
+	i.Set("42")
+	if h.Rand().Uint64() != i.Rand().Uint64() {
+		t.Error("SeedFlag set to 42 is not deterministic")
+	}
+	if err := (&SeedFlag{}).Set("not a seed"); err == nil {
+		t.Error(`Set("not a seed") = <nil>`)
+	}
+	var nilFlag *SeedFlag
+	if s := nilFlag.String(); s != "" {
+		t.Errorf("(*SeedFlag)(nil).String() = %q", s)
+	}
+}
@@ -0,0 +1,65 @@
+package rnd
+
+import "iter"
+
+// Walk returns an infinite random walk, starting at start, where every step
+// is normally distributed with mean 0 and standard deviation step.
+func Walk(start, step float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for x := start; yield(x); x += step * NormFloat64() {
+		}
+	}
+}
+
+// WalkInt returns an infinite random walk on the integers, starting at
+// start, where every step is either +1 or -1 with equal probability.
+func WalkInt(start int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for x := start; yield(x); x += 2*int(Uint32()&1) - 1 {
+		}
+	}
+}
+
+// seq returns an infinite iterator over the values returned by f.
+func seq[T any](f func() T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for yield(f()) {
+		}
+	}
+}
+
+// Uint64Seq returns an infinite iterator over values as returned by Uint64.
+func Uint64Seq() iter.Seq[uint64] {
+	return seq(Uint64)
+}
+
+// Int63Seq returns an infinite iterator over values as returned by Int63.
+func Int63Seq() iter.Seq[int64] {
+	return seq(Int63)
+}
+
+// IntnSeq returns an infinite iterator over values as returned by Intn(n).
+// It panics if n <= 0.
+func IntnSeq(n int) iter.Seq[int] {
+	if n <= 0 {
+		panic("invalid argument to IntnSeq")
+	}
+	return seq(func() int { return Intn(n) })
+}
+
+// Float64Seq returns an infinite iterator over values as returned by Float64.
+func Float64Seq() iter.Seq[float64] {
+	return seq(Float64)
+}
+
+// NormFloat64Seq returns an infinite iterator over values as returned by
+// NormFloat64.
+func NormFloat64Seq() iter.Seq[float64] {
+	return seq(NormFloat64)
+}
+
+// ExpFloat64Seq returns an infinite iterator over values as returned by
+// ExpFloat64.
+func ExpFloat64Seq() iter.Seq[float64] {
+	return seq(ExpFloat64)
+}
@@ -0,0 +1,71 @@
+package rnd
+
+import (
+	"iter"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	var n int
+	for x := range Walk(42, 0) {
+		if x != 42 {
+			t.Fatalf("Walk(42, 0) yielded %v", x)
+		}
+		if n++; n == 10 {
+			break
+		}
+	}
+	last, n := 0, 0
+	for x := range WalkInt(0) {
+		if n > 0 && x != last+1 && x != last-1 {
+			t.Fatalf("WalkInt(0) yielded %d after %d", x, last)
+		}
+		if n == 0 && x != 0 {
+			t.Fatalf("WalkInt(0) started at %d", x)
+		}
+		if last, n = x, n+1; n == 100 {
+			break
+		}
+	}
+}
+
+func TestSeqs(t *testing.T) {
+	take := func(s iter.Seq[float64]) []float64 {
+		var out []float64
+		for v := range s {
+			if out = append(out, v); len(out) == 10 {
+				break
+			}
+		}
+		return out
+	}
+	for _, v := range take(Float64Seq()) {
+		if v < 0 || v >= 1 {
+			t.Fatalf("Float64Seq yielded %v", v)
+		}
+	}
+	for _, v := range take(ExpFloat64Seq()) {
+		if v <= 0 {
+			t.Fatalf("ExpFloat64Seq yielded %v", v)
+		}
+	}
+	take(NormFloat64Seq())
+	var n int
+	for v := range IntnSeq(3) {
+		if v < 0 || v >= 3 {
+			t.Fatalf("IntnSeq(3) yielded %d", v)
+		}
+		if n++; n == 10 {
+			break
+		}
+	}
+	for range Uint64Seq() {
+		break
+	}
+	for v := range Int63Seq() {
+		if v < 0 {
+			t.Fatalf("Int63Seq yielded %d", v)
+		}
+		break
+	}
+}
@@ -0,0 +1,188 @@
+package rnd
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// WeightedShuffle pseudo-randomizes the order of elements of s, so elements
+// with higher weight tend to appear earlier. More precisely, the order is the
+// same as when repeatedly drawing the next element from the remaining ones,
+// with probability proportional to their weights. Elements with weight 0 come
+// last, in random order. weights is not modified.
+//
+// WeightedShuffle panics if len(weights) != len(s) or any weight is negative
+// or NaN.
+func WeightedShuffle[T any](s []T, weights []float64) {
+	if len(weights) != len(s) {
+		panic("mismatched lengths in WeightedShuffle")
+	}
+	// Sorting by E/w, with E exponentially distributed, gives the desired
+	// order. See Efraimidis and Spirakis, "Weighted random sampling with a
+	// reservoir", 2006.
+	type item struct {
+		key float64
+		i   int
+	}
+	items := make([]item, len(s))
+	for i, w := range weights {
+		if !(w >= 0) {
+			panic("invalid weight in WeightedShuffle")
+		}
+		key := math.Inf(1)
+		if w > 0 {
+			key = ExpFloat64() / w
+		}
+		items[i] = item{key, i}
+	}
+	// Keys of zero-weight elements are all +Inf, so shuffle before sorting
+	// stably, to randomize their order.
+	Shuffle(items)
+	slices.SortStableFunc(items, func(a, b item) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	tmp := slices.Clone(s)
+	for j, it := range items {
+		s[j] = tmp[it.i]
+	}
+}
+
+// ShuffleAny pseudo-randomizes the order of elements of the slice s, which
+// can be of any slice type. It panics if s is not a slice.
+func ShuffleAny(s any) {
+	swap := reflect.Swapper(s)
+	ShuffleFunc(reflect.ValueOf(s).Len(), swap)
+}
+
+// ShuffleTogether pseudo-randomizes the order of elements of the parallel
+// slices in ss, applying the same permutation to all of them. Elements at the
+// same index before shuffling are at the same index afterwards, so it can be
+// used to shuffle features with their labels or keys with their values.
+//
+// ShuffleTogether panics if any argument is not a slice or if the slices have
+// different lengths.
+func ShuffleTogether(ss ...any) {
+	if len(ss) == 0 {
+		return
+	}
+	n := reflect.ValueOf(ss[0]).Len()
+	swaps := make([]func(i, j int), len(ss))
+	for k, s := range ss {
+		swaps[k] = reflect.Swapper(s)
+		if reflect.ValueOf(s).Len() != n {
+			panic("mismatched lengths in ShuffleTogether")
+		}
+	}
+	ShuffleFunc(n, func(i, j int) {
+		for _, swap := range swaps {
+			swap(i, j)
+		}
+	})
+}
+
+// Interleave returns a uniformly random merge of seqs, which preserves the
+// order of elements within each of them. Every interleaving is equally
+// likely: the next element is taken from each input with probability
+// proportional to the number of its remaining elements.
+//
+// It is meant for producing merged event logs from independent traces, for
+// example.
+func Interleave[T any](seqs ...[]T) []T {
+	var n int
+	for _, s := range seqs {
+		n += len(s)
+	}
+	out := make([]T, 0, n)
+	for v := range InterleaveSeq(seqs...) {
+		out = append(out, v)
+	}
+	return out
+}
+
+// InterleaveSeq is like Interleave, but yields the elements of the merge one
+// by one, instead of collecting them into a slice.
+func InterleaveSeq[T any](seqs ...[]T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		pos := make([]int, len(seqs))
+		var rem int
+		for _, s := range seqs {
+			rem += len(s)
+		}
+		for ; rem > 0; rem-- {
+			k := Intn(rem)
+			for i, s := range seqs {
+				if k < len(s)-pos[i] {
+					if !yield(s[pos[i]]) {
+						return
+					}
+					pos[i]++
+					break
+				}
+				k -= len(s) - pos[i]
+			}
+		}
+	}
+}
+
+// Cycle returns, as a slice of n ints, a pseudo-random cyclic permutation of
+// the integers [0,n), using Sattolo's algorithm. Following i → p[i] from any
+// element visits all n elements before returning to it, so p describes a
+// random ring, like an order for passing a token. All (n-1)! such permutations
+// are equally likely. Perm, in contrast, usually yields several shorter
+// cycles and fixed points.
+//
+// For n == 1, the only cycle is the fixed point [0]. Cycle panics if n < 0.
+func Cycle(n int) []int {
+	if n < 0 {
+		panic("invalid argument to Cycle")
+	}
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	WithRand(func(r *Rand) {
+		for i := n - 1; i > 0; i-- {
+			j := r.Intn(i)
+			p[i], p[j] = p[j], p[i]
+		}
+	})
+	return p
+}
+
+// Bijection returns a uniformly random one-to-one mapping of the elements of
+// domain onto themselves. It is meant for renaming or pseudonymizing test
+// data. The mapping can be reversed by inverting the map.
+//
+// Bijection panics if domain contains duplicates.
+func Bijection[T comparable](domain []T) map[T]T {
+	return BijectionTo(domain, domain)
+}
+
+// BijectionTo returns a uniformly random one-to-one mapping of the elements
+// of domain onto those of codomain.
+//
+// BijectionTo panics if domain and codomain have different lengths or either
+// of them contains duplicates.
+func BijectionTo[T, U comparable](domain []T, codomain []U) map[T]U {
+	if len(domain) != len(codomain) {
+		panic("mismatched lengths in BijectionTo")
+	}
+	seen := make(map[U]bool, len(codomain))
+	for _, v := range codomain {
+		if seen[v] {
+			panic("duplicate element in BijectionTo")
+		}
+		seen[v] = true
+	}
+	m := make(map[T]U, len(domain))
+	for i, j := range Perm(len(domain)) {
+		if _, ok := m[domain[i]]; ok {
+			panic("duplicate element in BijectionTo")
+		}
+		m[domain[i]] = codomain[j]
+	}
+	return m
+}
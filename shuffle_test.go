@@ -0,0 +1,175 @@
+package rnd
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWeightedShuffle(t *testing.T) {
+	const n = 10000
+	var first [3]int
+	for i := 0; i < n; i++ {
+		s := []int{0, 1, 2, 3}
+		WeightedShuffle(s, []float64{1, 2, 7, 0})
+		if s[3] != 3 {
+			t.Fatalf("WeightedShuffle put zero-weight element at %d: %v", slices.Index(s, 3), s)
+		}
+		sorted := slices.Clone(s)
+		slices.Sort(sorted)
+		if !slices.Equal(sorted, []int{0, 1, 2, 3}) {
+			t.Fatalf("WeightedShuffle returned %v, which is not a permutation", s)
+		}
+		first[s[0]]++
+	}
+	for i, w := range []float64{0.1, 0.2, 0.7} {
+		if got := float64(first[i]) / n; got < w-0.03 || got > w+0.03 {
+			t.Errorf("element %d came first with probability %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestShuffleAny(t *testing.T) {
+	var s any = []string{"a", "b", "c", "d"}
+	ShuffleAny(s)
+	got := slices.Clone(s.([]string))
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"a", "b", "c", "d"}) {
+		t.Errorf("ShuffleAny returned %v, which is not a permutation", s)
+	}
+	ShuffleAny([]int(nil))
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ShuffleAny(42) did not panic")
+		}
+	}()
+	ShuffleAny(42)
+}
+
+func TestShuffleTogether(t *testing.T) {
+	keys := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	vals := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+	sq := []float64{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}
+	ShuffleTogether(keys, vals, sq)
+	for i, k := range keys {
+		if vals[i] != strconv.Itoa(k) || sq[i] != float64(k*k) {
+			t.Fatalf("ShuffleTogether did not keep slices aligned: %v, %v, %v", keys, vals, sq)
+		}
+	}
+	ShuffleTogether()
+	ShuffleTogether([]int(nil), []string{})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ShuffleTogether with different lengths did not panic")
+		}
+	}()
+	ShuffleTogether([]int{1, 2}, []int{1})
+}
+
+func TestInterleave(t *testing.T) {
+	const n = 9000
+	// There are three interleavings of [0 1] and [2], determined by the
+	// position of 2.
+	var pos [3]int
+	for i := 0; i < n; i++ {
+		s := Interleave([]int{0, 1}, nil, []int{2})
+		j := slices.Index(s, 2)
+		if len(s) != 3 || j < 0 || slices.Index(s, 0) > slices.Index(s, 1) {
+			t.Fatalf("Interleave returned %v, which is not an interleaving", s)
+		}
+		pos[j]++
+	}
+	for j, c := range pos {
+		if got := float64(c) / n; got < 0.3 || got > 0.37 {
+			t.Errorf("2 was at position %d with probability %v, want 1/3", j, got)
+		}
+	}
+	if s := Interleave[int](); len(s) != 0 {
+		t.Errorf("Interleave() = %v, want empty", s)
+	}
+	for v := range InterleaveSeq([]int{1, 2, 3}, []int{4, 5}) {
+		if v > 3 {
+			break
+		}
+	}
+}
+
+func TestCycle(t *testing.T) {
+	// There are 3! = 6 cycles of length 4.
+	seen := make(map[[4]int]int)
+	for range 6000 {
+		p := Cycle(4)
+		i, steps := 0, 0
+		for {
+			i = p[i]
+			steps++
+			if i == 0 {
+				break
+			}
+			if steps > 4 {
+				t.Fatalf("Cycle(4) = %v, which is not a permutation", p)
+			}
+		}
+		if steps != 4 {
+			t.Fatalf("Cycle(4) = %v, which is not a single cycle", p)
+		}
+		seen[[4]int(p)]++
+	}
+	if len(seen) != 6 {
+		t.Errorf("Cycle(4) returned %d different cycles, want 6", len(seen))
+	}
+	for p, c := range seen {
+		if c < 850 || c > 1150 {
+			t.Errorf("Cycle(4) returned %v %d times, want about 1000", p, c)
+		}
+	}
+	if p := Cycle(1); !slices.Equal(p, []int{0}) {
+		t.Errorf("Cycle(1) = %v, want [0]", p)
+	}
+	if p := Cycle(0); len(p) != 0 {
+		t.Errorf("Cycle(0) = %v, want empty", p)
+	}
+}
+
+func TestBijection(t *testing.T) {
+	domain := []string{"alice", "bob", "carol", "dave"}
+	var fixed int
+	for range 1000 {
+		m := Bijection(domain)
+		if len(m) != len(domain) {
+			t.Fatalf("Bijection(%v) = %v, want %d entries", domain, m, len(domain))
+		}
+		seen := make(map[string]bool)
+		for k, v := range m {
+			if !slices.Contains(domain, k) || !slices.Contains(domain, v) || seen[v] {
+				t.Fatalf("Bijection(%v) = %v, which is not a bijection", domain, m)
+			}
+			seen[v] = true
+			if k == v {
+				fixed++
+			}
+		}
+	}
+	// Every element is a fixed point with probability 1/4.
+	if fixed < 850 || fixed > 1150 {
+		t.Errorf("Bijection had %d fixed points in 1000 calls, want about 1000", fixed)
+	}
+	m := BijectionTo([]int{1, 2, 3}, []string{"x", "y", "z"})
+	if len(m) != 3 || m[1] == m[2] || m[2] == m[3] || m[1] == m[3] {
+		t.Errorf("BijectionTo returned %v, which is not a bijection", m)
+	}
+	for _, f := range []func(){
+		func() { Bijection([]int{1, 2, 1}) },
+		func() { BijectionTo([]int{1, 2}, []int{1}) },
+		func() { BijectionTo([]int{1, 2}, []int{3, 3}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("invalid call did not panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,54 @@
+package rnd
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+
+	"gonih.org/rnd/internal/testhook"
+)
+
+// Source is a source of random numbers, backed by the global source used by
+// the package-level functions. It is safe for concurrent use.
+//
+// Source implements the Source interfaces of math/rand/v2 and
+// golang.org/x/exp/rand. That makes it usable with the distributions of
+// gonum.org/v1/gonum/stat/distuv, for example:
+//
+//	n := distuv.Normal{Mu: 0, Sigma: 1, Src: rnd.Source{}}
+type Source struct{}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+func (Source) Uint64() uint64 {
+	return Uint64()
+}
+
+// Seed panics, as the global source can not be seeded. It only exists to
+// implement the Source interface of golang.org/x/exp/rand.
+func (Source) Seed(uint64) {
+	panic("rnd: the global source can not be seeded")
+}
+
+// customSource is set, if the global source has been replaced by SetSource.
+// It disables re-seeding.
+var customSource atomic.Bool
+
+// SetSource replaces the global source by s, for example to use a hardware
+// random number generator or an instrumented source. s must be safe for
+// concurrent use. The package never re-seeds s. Calling SetSource with a nil
+// s restores a randomly seeded default source.
+//
+// SetSource must be called before the global source is first used, usually
+// at the start of main. It panics otherwise, unless s is nil. Libraries must
+// not call it. Tests can use rndtest.SetSource instead.
+func SetSource(s rand.Source) {
+	if s != nil && generated() > 0 && testhook.LateSetSource.Load() <= 0 {
+		panic("rnd: SetSource called after the global source has been used")
+	}
+	if s == nil {
+		customSource.Store(false)
+		src.seed(newSeed())
+		return
+	}
+	customSource.Store(true)
+	src.set(s)
+}
@@ -0,0 +1,73 @@
+package rnd
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"gonih.org/rnd/internal/testhook"
+)
+
+func init() {
+	// Tests of this package replace the global source after it has been
+	// used.
+	testhook.LateSetSource.Add(1)
+}
+
+var (
+	_ rand.Source = Source{}
+	// The Source interface of golang.org/x/exp/rand, as used by gonum.
+	_ interface {
+		Uint64() uint64
+		Seed(uint64)
+	} = Source{}
+)
+
+type constSource uint64
+
+func (s constSource) Uint64() uint64 { return uint64(s) }
+
+func TestSetSource(t *testing.T) {
+	SetSource(constSource(42))
+	defer SetSource(nil)
+	for i := 0; i < 10; i++ {
+		if v := Uint64(); v != 42 {
+			t.Fatalf("Uint64() = %d with constant source, want 42", v)
+		}
+	}
+	Reseed()
+	if v := Uint64(); v != 42 {
+		t.Fatalf("Uint64() = %d after Reseed, want 42", v)
+	}
+	SetSource(nil)
+	if Uint64() == 42 && Uint64() == 42 {
+		t.Fatal("SetSource(nil) did not restore the default source")
+	}
+}
+
+func TestSetSourceLate(t *testing.T) {
+	Uint64()
+	testhook.LateSetSource.Add(-1)
+	defer testhook.LateSetSource.Add(1)
+	defer func() {
+		if recover() == nil {
+			t.Error("SetSource after use did not panic")
+		}
+	}()
+	// Restoring the default source is always allowed.
+	SetSource(nil)
+	SetSource(constSource(42))
+	SetSource(nil)
+}
+
+func TestSourceV2(t *testing.T) {
+	// Source can be used with math/rand/v2 directly.
+	r := rand.New(Source{})
+	if v := r.IntN(10); v < 0 || v >= 10 {
+		t.Errorf("rand.New(Source{}).IntN(10) = %d", v)
+	}
+	before := Stats().Generated
+	r.Uint64()
+	if Stats().Generated == before {
+		t.Error("rand.New(Source{}) does not draw from the global source")
+	}
+}
@@ -0,0 +1,45 @@
+package rnd
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var stats struct {
+	// retired is the number of calls counted before the most recent reset of
+	// calls. The values generated in total are retired plus calls.
+	retired    atomic.Uint64
+	reseeds    atomic.Uint64
+	lastReseed atomic.Int64
+}
+
+// Statistics contains usage statistics of the global source.
+type Statistics struct {
+	// Generated is the approximate number of 64-bit values drawn from the
+	// global source.
+	Generated uint64
+	// Reseeds is the number of times the global source has been re-seeded.
+	Reseeds uint64
+	// LastReseed is the time of the most recent re-seed. It is the zero
+	// time, if the global source has not been re-seeded yet.
+	LastReseed time.Time
+}
+
+// Stats returns usage statistics of the global source. It is meant for
+// verifying the re-seeding policy in long-running programs.
+func Stats() Statistics {
+	st := Statistics{
+		Generated: generated(),
+		Reseeds:   stats.reseeds.Load(),
+	}
+	if ns := stats.lastReseed.Load(); ns != 0 {
+		st.LastReseed = time.Unix(0, ns)
+	}
+	return st
+}
+
+// generated returns the approximate number of values drawn from the global
+// source.
+func generated() uint64 {
+	return stats.retired.Load() + calls.load()
+}
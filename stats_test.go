@@ -0,0 +1,35 @@
+package rnd
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	before := Stats()
+	Uint64()
+	Perm(10)
+	after := Stats()
+	if d := after.Generated - before.Generated; d < 11 {
+		t.Errorf("Generated increased by %d, want at least 11", d)
+	}
+	if after.Reseeds < before.Reseeds {
+		t.Errorf("Reseeds decreased from %d to %d", before.Reseeds, after.Reseeds)
+	}
+}
+
+func TestReseed(t *testing.T) {
+	if debugSeeded.Load() {
+		t.Skip("debug seeding is enabled")
+	}
+	before := Stats()
+	reseed(1 << 32)
+	after := Stats()
+	if after.Reseeds != before.Reseeds+1 {
+		t.Errorf("Reseeds = %d, want %d", after.Reseeds, before.Reseeds+1)
+	}
+	if after.LastReseed.IsZero() {
+		t.Error("LastReseed is zero after re-seeding")
+	}
+	reseed(1)
+	if s := Stats(); s.Reseeds != after.Reseeds {
+		t.Errorf("reseed(1) re-seeded again: Reseeds = %d, want %d", s.Reseeds, after.Reseeds)
+	}
+}
@@ -0,0 +1,193 @@
+// Package stattest provides statistical smoke tests for sources of random
+// numbers.
+//
+// The tests are meant to catch broken sources, like ones with a bad seed or a
+// bug in their implementation. They are not a substitute for thorough test
+// suites like TestU01 or PractRand.
+//
+// Every test returns a p-value. For a good source, p-values are uniformly
+// distributed in [0,1], so even a good source will occasionally produce small
+// values. Check uses a threshold, which makes spurious failures very
+// unlikely.
+package stattest
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// threshold is the p-value below which Check considers a test failed.
+const threshold = 1e-6
+
+// Check runs all tests in this package on src, with default sizes. It returns
+// an error, if any of them fails.
+func Check(src rand.Source) error {
+	const n = 1 << 16
+	if p := Monobit(src, n); p < threshold {
+		return fmt.Errorf("monobit test failed: p = %g", p)
+	}
+	if p := Runs(src, n); p < threshold {
+		return fmt.Errorf("runs test failed: p = %g", p)
+	}
+	if p := ChiSquare(src, n, 256); p < threshold {
+		return fmt.Errorf("chi-square test failed: p = %g", p)
+	}
+	return nil
+}
+
+// Monobit performs the frequency (monobit) test from NIST SP 800-22 on the
+// bits of n values from src. It returns the p-value.
+func Monobit(src rand.Source, n int) float64 {
+	N := 64 * float64(n)
+	var ones int
+	for i := 0; i < n; i++ {
+		ones += bits.OnesCount64(src.Uint64())
+	}
+	s := math.Abs(2*float64(ones)-N) / math.Sqrt(N)
+	return math.Erfc(s / math.Sqrt2)
+}
+
+// Runs performs the runs test from NIST SP 800-22 on the bits of n values
+// from src. It returns the p-value.
+func Runs(src rand.Source, n int) float64 {
+	N := 64 * float64(n)
+	var ones, transitions int
+	var last uint64
+	for i := 0; i < n; i++ {
+		v := src.Uint64()
+		ones += bits.OnesCount64(v)
+		// Bit k of v^(v>>1) is set, if bits k and k+1 of v differ.
+		transitions += bits.OnesCount64((v ^ (v >> 1)) &^ (1 << 63))
+		if i > 0 && last>>63 != v&1 {
+			transitions++
+		}
+		last = v
+	}
+	pi := float64(ones) / N
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(N) {
+		// The prerequisite frequency test failed.
+		return 0
+	}
+	V := float64(transitions + 1)
+	return math.Erfc(math.Abs(V-2*N*pi*(1-pi)) / (2 * math.Sqrt(2*N) * pi * (1 - pi)))
+}
+
+// ChiSquare performs a chi-square test for uniformity, by sorting n values
+// from src into the given number of buckets. It returns the p-value.
+func ChiSquare(src rand.Source, n, buckets int) float64 {
+	if buckets < 2 {
+		panic("invalid number of buckets")
+	}
+	counts := make([]int, buckets)
+	for i := 0; i < n; i++ {
+		b, _ := bits.Mul64(src.Uint64(), uint64(buckets))
+		counts[b]++
+	}
+	return chiSquare(counts, float64(n)/float64(buckets))
+}
+
+// CheckShuffle tests shuffle, an implementation of shuffling or sampling a
+// permutation, for bias. It calls shuffle trials times on the slice
+// [0 1 … n-1], counts how often every value ends up at every position and runs
+// a chi-square test on those counts. It returns an error, if the test fails
+// or shuffle does not produce a permutation.
+//
+// For the test to be meaningful, trials should be at least 5n. CheckShuffle
+// panics if n < 2 or trials < 1.
+func CheckShuffle(n, trials int, shuffle func([]int)) error {
+	if n < 2 || trials < 1 {
+		panic("invalid argument to CheckShuffle")
+	}
+	counts := make([]int, n*n)
+	s := make([]int, n)
+	seen := make([]bool, n)
+	for range trials {
+		for i := range s {
+			s[i] = i
+		}
+		shuffle(s)
+		clear(seen)
+		for i, v := range s {
+			if v < 0 || v >= n || seen[v] {
+				return fmt.Errorf("shuffle returned %v, which is not a permutation", s)
+			}
+			seen[v] = true
+			counts[i*n+v]++
+		}
+	}
+	// Every row and column sums to trials, so there are (n-1)² degrees of
+	// freedom.
+	e := float64(trials) / float64(n)
+	var x float64
+	for _, c := range counts {
+		d := float64(c) - e
+		x += d * d / e
+	}
+	df := float64((n - 1) * (n - 1))
+	if p := gammaQ(df/2, x/2); p < threshold {
+		return fmt.Errorf("shuffle is biased: p = %g", p)
+	}
+	return nil
+}
+
+// chiSquare returns the p-value of the chi-square statistic of counts, given
+// that each count is expected to be e.
+func chiSquare(counts []int, e float64) float64 {
+	var x float64
+	for _, c := range counts {
+		d := float64(c) - e
+		x += d * d / e
+	}
+	return gammaQ(float64(len(counts)-1)/2, x/2)
+}
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a, x).
+//
+// See Numerical Recipes, section 6.2.
+func gammaQ(a, x float64) float64 {
+	const (
+		eps  = 1e-15
+		tiny = 1e-300
+	)
+	if x <= 0 {
+		return 1
+	}
+	lg, _ := math.Lgamma(a)
+	pre := math.Exp(-x + a*math.Log(x) - lg)
+	if x < a+1 {
+		// Series representation of P(a, x).
+		ap, del := a, 1/a
+		sum := del
+		for i := 0; i < 1000 && math.Abs(del) > math.Abs(sum)*eps; i++ {
+			ap++
+			del *= x / ap
+			sum += del
+		}
+		return 1 - sum*pre
+	}
+	// Continued fraction representation of Q(a, x), using Lentz's method.
+	b := x + 1 - a
+	c, d := 1/tiny, 1/b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h * pre
+}
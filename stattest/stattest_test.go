@@ -0,0 +1,72 @@
+package stattest
+
+import (
+	"math"
+	"testing"
+
+	"gonih.org/rnd"
+)
+
+func TestPackageSources(t *testing.T) {
+	if err := Check(rnd.Global()); err != nil {
+		t.Errorf("global source: %v", err)
+	}
+	if err := Check(rnd.Derive("stattest")); err != nil {
+		t.Errorf("derived source: %v", err)
+	}
+}
+
+type constSource uint64
+
+func (s constSource) Uint64() uint64 { return uint64(s) }
+
+type counter uint64
+
+func (c *counter) Uint64() uint64 { *c++; return uint64(*c) }
+
+func TestBadSources(t *testing.T) {
+	if p := Monobit(constSource(0), 1000); p > threshold {
+		t.Errorf("Monobit(0) = %g, want < %g", p, threshold)
+	}
+	if p := Runs(constSource(0xaaaaaaaaaaaaaaaa), 1000); p > threshold {
+		t.Errorf("Runs(0xaaaa…) = %g, want < %g", p, threshold)
+	}
+	if p := ChiSquare(new(counter), 1000, 16); p > threshold {
+		t.Errorf("ChiSquare(counter) = %g, want < %g", p, threshold)
+	}
+	if err := Check(constSource(0x5555555555555555)); err == nil {
+		t.Errorf("Check(0x5555…) succeeded")
+	}
+}
+
+func TestGammaQ(t *testing.T) {
+	// Q(1, x) = exp(-x) and Q(1/2, x) = erfc(sqrt(x))
+	for _, x := range []float64{0.1, 1, 2, 5, 20} {
+		if got, want := gammaQ(1, x), math.Exp(-x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("gammaQ(1, %v) = %v, want %v", x, got, want)
+		}
+		if got, want := gammaQ(0.5, x), math.Erfc(math.Sqrt(x)); math.Abs(got-want) > 1e-12 {
+			t.Errorf("gammaQ(0.5, %v) = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestCheckShuffle(t *testing.T) {
+	if err := CheckShuffle(10, 10000, rnd.Shuffle[int]); err != nil {
+		t.Errorf("CheckShuffle(rnd.Shuffle): %v", err)
+	}
+	// A common mistake is to swap every element with one at any position,
+	// instead of with one at the same or a later position.
+	naive := func(s []int) {
+		for i := range s {
+			j := rnd.Intn(len(s))
+			s[i], s[j] = s[j], s[i]
+		}
+	}
+	if err := CheckShuffle(10, 10000, naive); err == nil {
+		t.Errorf("CheckShuffle(naive) succeeded")
+	}
+	if err := CheckShuffle(3, 10, func(s []int) { s[0] = s[1] }); err == nil {
+		t.Errorf("CheckShuffle with duplicate value succeeded")
+	}
+}
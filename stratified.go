@@ -0,0 +1,75 @@
+package rnd
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Stratified returns n pseudo-random numbers in [0,1), where the i'th number
+// is drawn uniformly from the stratum [i/n,(i+1)/n). Compared to n
+// independent draws, this reduces the variance of Monte-Carlo estimates.
+// It panics if n < 0.
+func Stratified(n int) []float64 {
+	if n < 0 {
+		panic("invalid argument to Stratified")
+	}
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = min((float64(i)+Float64())/float64(n), math.Nextafter(1, 0))
+	}
+	return out
+}
+
+// StratifiedSample returns a pseudo-random sample of n elements of items,
+// drawn without replacement. items are grouped by key and every group
+// contributes to the sample in proportion to its size, rounded using the
+// largest remainder method. The sample is ordered by group, in order of first
+// appearance in items, and randomly within each group.
+//
+// StratifiedSample panics if n < 0 or n > len(items).
+func StratifiedSample[T any, K comparable](items []T, key func(T) K, n int) []T {
+	if n < 0 || n > len(items) {
+		panic("invalid argument to StratifiedSample")
+	}
+	type group struct {
+		idx   []int
+		quota int
+		rem   int
+	}
+	var groups []*group
+	byKey := make(map[K]*group)
+	for i, it := range items {
+		k := key(it)
+		g := byKey[k]
+		if g == nil {
+			g = new(group)
+			byKey[k] = g
+			groups = append(groups, g)
+		}
+		g.idx = append(g.idx, i)
+	}
+	left := n
+	for _, g := range groups {
+		g.quota = n * len(g.idx) / len(items)
+		g.rem = n * len(g.idx) % len(items)
+		left -= g.quota
+	}
+	// Hand out the remaining slots to the groups with the largest remainders,
+	// breaking ties randomly.
+	order := slices.Clone(groups)
+	Shuffle(order)
+	slices.SortStableFunc(order, func(a, b *group) int {
+		return cmp.Compare(b.rem, a.rem)
+	})
+	for _, g := range order[:left] {
+		g.quota++
+	}
+	out := make([]T, 0, n)
+	for _, g := range groups {
+		for _, i := range PickK(len(g.idx), g.quota) {
+			out = append(out, items[g.idx[i]])
+		}
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package rnd
+
+import "testing"
+
+func TestStratified(t *testing.T) {
+	const n = 100
+	for i, v := range Stratified(n) {
+		if v < float64(i)/n || v >= float64(i+1)/n {
+			t.Fatalf("Stratified(%d)[%d] = %v", n, i, v)
+		}
+	}
+}
+
+func TestStratifiedSample(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	// Two groups, of 70 and 30 items.
+	key := func(v int) bool { return v < 70 }
+	got := StratifiedSample(items, key, 10)
+	if len(got) != 10 {
+		t.Fatalf("len(StratifiedSample(…, 10)) = %d", len(got))
+	}
+	var small int
+	seen := make(map[int]bool)
+	for _, v := range got {
+		if seen[v] {
+			t.Fatalf("StratifiedSample returned %d twice", v)
+		}
+		seen[v] = true
+		if v < 70 {
+			small++
+		}
+	}
+	if small != 7 {
+		t.Errorf("StratifiedSample sampled %d items of the larger group, want 7", small)
+	}
+
+	// Three groups of equal size leave one slot to one of them.
+	got = StratifiedSample(items[:99], func(v int) int { return v % 3 }, 4)
+	if len(got) != 4 {
+		t.Fatalf("len(StratifiedSample(…, 4)) = %d", len(got))
+	}
+}
@@ -0,0 +1,93 @@
+package rnd
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TextModel generates random text, imitating a training corpus. It is an
+// order-k Markov chain over characters or words: every token is chosen based
+// on the k tokens preceding it, with the frequency with which it follows them
+// in the corpus. A TextModel is safe for concurrent use.
+type TextModel struct {
+	order  int
+	sep    string
+	tokens []string
+	// next maps every context of order tokens, joined by \x00, to the tokens
+	// following it, with repetitions.
+	next map[string][]string
+}
+
+// NewCharModel returns an order-k TextModel over the runes of text. Small
+// orders, like 3 or 4, produce pronounceable gibberish, larger orders produce
+// increasingly verbatim excerpts of text. It panics if order < 1 or text has
+// fewer than order+1 runes.
+func NewCharModel(text string, order int) *TextModel {
+	var tokens []string
+	for len(text) > 0 {
+		_, n := utf8.DecodeRuneInString(text)
+		tokens = append(tokens, text[:n])
+		text = text[n:]
+	}
+	return newTextModel(tokens, order, "", "invalid argument to NewCharModel")
+}
+
+// NewWordModel returns an order-k TextModel over the words of text, as split
+// by strings.Fields. Punctuation is part of words. Orders of 1 or 2 give the
+// most natural results for typical corpora. It panics if order < 1 or text has
+// fewer than order+1 words.
+func NewWordModel(text string, order int) *TextModel {
+	return newTextModel(strings.Fields(text), order, " ", "invalid argument to NewWordModel")
+}
+
+func newTextModel(tokens []string, order int, sep, msg string) *TextModel {
+	if order < 1 || len(tokens) <= order {
+		panic(msg)
+	}
+	m := &TextModel{
+		order:  order,
+		sep:    sep,
+		tokens: tokens,
+		next:   make(map[string][]string),
+	}
+	for i := order; i < len(tokens); i++ {
+		k := contextKey(tokens[i-order : i])
+		m.next[k] = append(m.next[k], tokens[i])
+	}
+	return m
+}
+
+func contextKey(ctx []string) string {
+	return strings.Join(ctx, "\x00")
+}
+
+// Generate returns a random text of n tokens, that is n runes for a model
+// returned by NewCharModel and n words for one from NewWordModel. Words are
+// separated by single spaces.
+//
+// The text starts at a random position of the corpus. If the generated text
+// reaches a context that only occurs at the end of the corpus, it continues
+// at another random position.
+func (m *TextModel) Generate(n int) string {
+	var sb strings.Builder
+	out := make([]string, 0, n)
+	for len(out) < n {
+		// Only start at positions with a successor.
+		i := Intn(len(m.tokens) - m.order)
+		out = append(out, m.tokens[i:i+m.order]...)
+		for len(out) < n {
+			succ := m.next[contextKey(out[len(out)-m.order:])]
+			if len(succ) == 0 {
+				break
+			}
+			out = append(out, succ[Intn(len(succ))])
+		}
+	}
+	for i, t := range out[:n] {
+		if i > 0 {
+			sb.WriteString(m.sep)
+		}
+		sb.WriteString(t)
+	}
+	return sb.String()
+}
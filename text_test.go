@@ -0,0 +1,63 @@
+package rnd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+const textCorpus = `It was the best of times, it was the worst of times, it was the age of
+wisdom, it was the age of foolishness, it was the epoch of belief, it was the
+epoch of incredulity, it was the season of Light, it was the season of
+Darkness, it was the spring of hope, it was the winter of despair.`
+
+func TestCharModel(t *testing.T) {
+	m := NewCharModel(textCorpus, 3)
+	for _, n := range []int{0, 1, 3, 500} {
+		s := m.Generate(n)
+		if c := utf8.RuneCountInString(s); c != n {
+			t.Fatalf("len(Generate(%d)) = %d runes", n, c)
+		}
+	}
+	if s := NewCharModel("abc", 2).Generate(7); s != "abcabca" {
+		t.Errorf(`NewCharModel("abc", 2).Generate(7) = %q, want "abcabca"`, s)
+	}
+}
+
+func TestWordModel(t *testing.T) {
+	m := NewWordModel(textCorpus, 1)
+	words := strings.Fields(m.Generate(200))
+	if len(words) != 200 {
+		t.Fatalf("Generate(200) returned %d words", len(words))
+	}
+	corpus := strings.Fields(textCorpus)
+	known := make(map[string]bool)
+	for _, w := range corpus {
+		known[w] = true
+	}
+	for _, w := range words {
+		if !known[w] {
+			t.Fatalf("Generate returned unknown word %q", w)
+		}
+	}
+	if s := NewWordModel("a b", 1).Generate(5); s != "a b a b a" {
+		t.Errorf(`NewWordModel("a b", 1).Generate(5) = %q, want "a b a b a"`, s)
+	}
+}
+
+func TestTextModelPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NewCharModel(ab, 2)": func() { NewCharModel("ab", 2) },
+		"NewCharModel(ab, 0)": func() { NewCharModel("ab", 0) },
+		"NewWordModel(a, 1)":  func() { NewWordModel("a", 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
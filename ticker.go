@@ -0,0 +1,104 @@
+package rnd
+
+import (
+	"sync"
+	"time"
+)
+
+// Ticker is like a time.Ticker, but randomizes the interval before each
+// tick. It is meant for periodic jobs, which should not run in lockstep
+// across processes.
+type Ticker struct {
+	// C is the channel on which the ticks are delivered. Like with
+	// time.Ticker, ticks are dropped for slow receivers.
+	C <-chan time.Time
+
+	c     chan time.Time
+	mu    sync.Mutex
+	d     time.Duration
+	frac  float64
+	exp   bool
+	timer *time.Timer
+	// gen is incremented by Stop and Reset, to ignore timers firing
+	// concurrently.
+	gen uint64
+}
+
+// NewTicker returns a new Ticker, with intervals as returned by
+// Jitter(d, frac). It panics if d <= 0 or frac is not in [0,1).
+func NewTicker(d time.Duration, frac float64) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	if !(frac >= 0 && frac < 1) {
+		panic("invalid argument to NewTicker")
+	}
+	return newTicker(d, frac, false)
+}
+
+// NewExpTicker returns a new Ticker, with exponentially distributed
+// intervals with the given mean. The ticks then form a Poisson process. It
+// panics if mean <= 0.
+func NewExpTicker(mean time.Duration) *Ticker {
+	if mean <= 0 {
+		panic("non-positive interval for NewExpTicker")
+	}
+	return newTicker(mean, 0, true)
+}
+
+func newTicker(d time.Duration, frac float64, exp bool) *Ticker {
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, c: c, d: d, frac: frac, exp: exp}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.schedule()
+	return t
+}
+
+// next returns the next interval. t.mu must be held.
+func (t *Ticker) next() time.Duration {
+	if t.exp {
+		return seconds(ExpFloat64() * t.d.Seconds())
+	}
+	return Jitter(t.d, t.frac)
+}
+
+// schedule starts the timer for the next tick. t.mu must be held.
+func (t *Ticker) schedule() {
+	gen := t.gen
+	t.timer = time.AfterFunc(t.next(), func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.gen != gen {
+			return
+		}
+		select {
+		case t.c <- time.Now():
+		default:
+		}
+		t.schedule()
+	})
+}
+
+// Stop turns off t. After Stop, no more ticks will be sent. Stop does not
+// close the channel.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.gen++
+	t.timer.Stop()
+}
+
+// Reset stops t and resets its mean interval to d. The next tick arrives
+// after a new randomized interval. It panics if d <= 0.
+func (t *Ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.gen++
+	t.timer.Stop()
+	t.d = d
+	t.schedule()
+}
@@ -0,0 +1,45 @@
+package rnd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicker(t *testing.T) {
+	for _, tk := range []*Ticker{NewTicker(time.Millisecond, 0.5), NewExpTicker(time.Millisecond)} {
+		for i := 0; i < 5; i++ {
+			select {
+			case <-tk.C:
+			case <-time.After(10 * time.Second):
+				t.Fatal("no tick received")
+			}
+		}
+		tk.Reset(time.Hour)
+		// Drain a tick that might have been sent before Reset.
+		select {
+		case <-tk.C:
+		default:
+		}
+		select {
+		case <-tk.C:
+			t.Error("tick received after Reset(time.Hour)")
+		case <-time.After(20 * time.Millisecond):
+		}
+		tk.Reset(time.Millisecond)
+		select {
+		case <-tk.C:
+		case <-time.After(10 * time.Second):
+			t.Fatal("no tick received after Reset")
+		}
+		tk.Stop()
+		select {
+		case <-tk.C:
+		default:
+		}
+		select {
+		case <-tk.C:
+			t.Error("tick received after Stop")
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package rnd
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Duration returns, as a time.Duration, a non-negative pseudo-random duration
+// in [0,max). It panics if max <= 0.
+func Duration(max time.Duration) time.Duration {
+	if max <= 0 {
+		panic("invalid argument to Duration")
+	}
+	return time.Duration(Int63n(int64(max)))
+}
+
+// DurationBetween returns, as a time.Duration, a pseudo-random duration in
+// [min,max). It panics if max <= min.
+func DurationBetween(min, max time.Duration) time.Duration {
+	if max <= min {
+		panic("invalid arguments to DurationBetween")
+	}
+	// max-min might overflow an int64, but is always representable as a
+	// uint64.
+	return min + time.Duration(Uint64n(uint64(max-min)))
+}
+
+// Jitter returns d, perturbed uniformly by up to ±frac·d. For example,
+// Jitter(time.Second, 0.1) returns a duration in [0.9s,1.1s). It panics if
+// frac is not in [0,1].
+func Jitter(d time.Duration, frac float64) time.Duration {
+	if !(frac >= 0 && frac <= 1) {
+		panic("invalid argument to Jitter")
+	}
+	return d + time.Duration((2*Float64()-1)*frac*float64(d))
+}
+
+// SleepJitter sleeps for Jitter(d, frac). It returns early with ctx.Err(), if
+// ctx is done before the sleep ends, otherwise it returns nil. It panics if
+// frac is not in [0,1].
+func SleepJitter(ctx context.Context, d time.Duration, frac float64) error {
+	t := time.NewTimer(Jitter(d, frac))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// TimeBetween returns a pseudo-random instant in [a,b), with nanosecond
+// precision. It panics if b is not after a.
+func TimeBetween(a, b time.Time) time.Time {
+	if !a.Before(b) {
+		panic("invalid arguments to TimeBetween")
+	}
+	if d := b.Sub(a); d < math.MaxInt64 {
+		return a.Add(Duration(d))
+	}
+	// The span can not be represented as a time.Duration. Instead, we choose
+	// the offset in whole seconds and nanoseconds uniformly and reject
+	// instants after b.
+	secs := uint64(b.Unix() - a.Unix())
+	for {
+		s, ns := int64(Uint64n(secs+1)), Int63n(1e9)
+		t := time.Unix(a.Unix()+s, int64(a.Nanosecond())+ns).In(a.Location())
+		if t.Before(b) {
+			return t
+		}
+	}
+}
+
+// day is the length of a day, as used by TimeOfDay.
+const day = 24 * time.Hour
+
+// TimeOfDay returns a pseudo-random time of day in [0,24h), as an offset from
+// midnight, clustered around peak. The time is drawn from a von Mises
+// distribution on the circle of a day, so it wraps around midnight. Larger
+// concentrations cluster the times more closely around peak. For
+// concentrations above about 10, the standard deviation is roughly
+// 24h/(2π·√concentration), so 4 gives about ±1.9h and 0 gives uniform times.
+// peak is taken modulo 24h.
+//
+// Mixing several calls with different peaks gives realistic daily traffic
+// patterns. TimeOfDay panics if concentration < 0.
+func TimeOfDay(peak time.Duration, concentration float64) time.Duration {
+	if !(concentration >= 0) {
+		panic("invalid argument to TimeOfDay")
+	}
+	mu := 2 * math.Pi * float64(peak%day) / float64(day)
+	t := time.Duration(VonMises(mu, concentration) / (2 * math.Pi) * float64(day))
+	return min(t, day-1)
+}
@@ -0,0 +1,105 @@
+package rnd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	for _, max := range []time.Duration{1, time.Second, time.Duration(1<<63 - 1)} {
+		for i := 0; i < 100; i++ {
+			if d := Duration(max); d < 0 || d >= max {
+				t.Fatalf("Duration(%v) = %v, want in [0,%[1]v)", max, d)
+			}
+		}
+	}
+}
+
+func TestDurationBetween(t *testing.T) {
+	tcs := []struct{ min, max time.Duration }{
+		{0, 1},
+		{-time.Second, time.Second},
+		{time.Second, time.Minute},
+		{-1 << 63, 1<<63 - 1},
+	}
+	for _, tc := range tcs {
+		for i := 0; i < 100; i++ {
+			if d := DurationBetween(tc.min, tc.max); d < tc.min || d >= tc.max {
+				t.Fatalf("DurationBetween(%v, %v) = %v", tc.min, tc.max, d)
+			}
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("DurationBetween(time.Second, 0) did not panic")
+		}
+	}()
+	DurationBetween(time.Second, 0)
+}
+
+func TestJitter(t *testing.T) {
+	for _, frac := range []float64{0, 0.1, 0.5, 1} {
+		lo, hi := time.Duration((1-frac)*float64(time.Second)), time.Duration((1+frac)*float64(time.Second))
+		for i := 0; i < 100; i++ {
+			if d := Jitter(time.Second, frac); d < lo || d > hi {
+				t.Fatalf("Jitter(1s, %v) = %v, want in [%v,%v]", frac, d, lo, hi)
+			}
+		}
+	}
+}
+
+func TestTimeBetween(t *testing.T) {
+	now := time.Now()
+	tcs := []struct{ a, b time.Time }{
+		{now, now.Add(1)},
+		{now, now.Add(time.Hour)},
+		{time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+	}
+	for _, tc := range tcs {
+		for i := 0; i < 100; i++ {
+			if got := TimeBetween(tc.a, tc.b); got.Before(tc.a) || !got.Before(tc.b) {
+				t.Fatalf("TimeBetween(%v, %v) = %v", tc.a, tc.b, got)
+			}
+		}
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	const n = 10000
+	var near int
+	for range n {
+		d := TimeOfDay(23*time.Hour+30*time.Minute, 20)
+		if d < 0 || d >= 24*time.Hour {
+			t.Fatalf("TimeOfDay = %v, want in [0,24h)", d)
+		}
+		// The standard deviation is about 51 minutes, so about 95% of times
+		// are within 2 hours of the peak, on both sides of midnight.
+		if d >= 21*time.Hour+30*time.Minute || d < 90*time.Minute {
+			near++
+		}
+	}
+	if near < 0.9*n {
+		t.Errorf("%d of %d times within 2h of peak, want about 95%%", near, n)
+	}
+}
+
+func TestSleepJitter(t *testing.T) {
+	start := time.Now()
+	if err := SleepJitter(context.Background(), 10*time.Millisecond, 0.5); err != nil {
+		t.Fatalf("SleepJitter = %v", err)
+	}
+	if d := time.Since(start); d < 5*time.Millisecond {
+		t.Errorf("SleepJitter(10ms, 0.5) slept for %v", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	if err := SleepJitter(ctx, time.Hour, 0.1); err != context.Canceled {
+		t.Errorf("SleepJitter with canceled context = %v, want %v", err, context.Canceled)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("SleepJitter with canceled context slept for %v", d)
+	}
+}
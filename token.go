@@ -0,0 +1,80 @@
+package rnd
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SeedToken identifies the stream of a generator: a seed and the backend it
+// is used with. It can be printed, for example when a randomized test fails,
+// and parsed again, to reproduce the stream.
+type SeedToken struct {
+	Seed    [32]byte
+	Backend Backend
+}
+
+// seedTokenEncoding is the encoding of SeedTokens. Tokens are printed in
+// lower case, but parsed case-insensitively.
+var seedTokenEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSeedToken returns a SeedToken with a random seed and the backend of the
+// global source.
+func NewSeedToken() SeedToken {
+	return SeedToken{Seed: newSeed(), Backend: defaultBackend}
+}
+
+// Rand returns a new Rand, producing the stream identified by t. Calling it
+// repeatedly returns generators producing the same stream.
+func (t SeedToken) Rand() *Rand {
+	return newRandOpts(t.Seed, []Option{WithBackend(t.Backend)})
+}
+
+// String encodes t as a string of 55 base32 characters. It contains a
+// checksum, so ParseSeedToken detects most typos.
+func (t SeedToken) String() string {
+	return strings.ToLower(seedTokenEncoding.EncodeToString(t.bytes()))
+}
+
+// bytes returns the binary encoding of t: the backend, the seed and one byte
+// of checksum.
+func (t SeedToken) bytes() []byte {
+	b := make([]byte, 0, 34)
+	b = append(b, byte(t.Backend))
+	b = append(b, t.Seed[:]...)
+	sum := sha256.Sum256(b)
+	return append(b, sum[0])
+}
+
+// ParseSeedToken parses a SeedToken, as returned by SeedToken.String.
+func ParseSeedToken(s string) (SeedToken, error) {
+	b, err := seedTokenEncoding.DecodeString(strings.ToUpper(strings.TrimSpace(s)))
+	if err != nil {
+		return SeedToken{}, fmt.Errorf("rnd: invalid seed token: %w", err)
+	}
+	if len(b) != 34 {
+		return SeedToken{}, errors.New("rnd: invalid seed token: wrong length")
+	}
+	var t SeedToken
+	t.Backend = Backend(b[0])
+	copy(t.Seed[:], b[1:33])
+	if t.Backend > Xoshiro256 {
+		return SeedToken{}, errors.New("rnd: invalid seed token: unknown backend")
+	}
+	if t.bytes()[33] != b[33] {
+		return SeedToken{}, errors.New("rnd: invalid seed token: checksum mismatch")
+	}
+	return t, nil
+}
+
+// NewFromToken returns a new Rand, producing the stream identified by the
+// token tok, as returned by SeedToken.String.
+func NewFromToken(tok string) (*Rand, error) {
+	t, err := ParseSeedToken(tok)
+	if err != nil {
+		return nil, err
+	}
+	return t.Rand(), nil
+}
@@ -0,0 +1,38 @@
+package rnd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSeedToken(t *testing.T) {
+	for _, b := range []Backend{ChaCha8, PCG, Xoshiro256} {
+		tok := NewSeedToken()
+		tok.Backend = b
+		s := tok.String()
+		if len(s) != 55 {
+			t.Errorf("len(%q) = %d, want 55", s, len(s))
+		}
+		r, err := NewFromToken(strings.ToUpper(s))
+		if err != nil {
+			t.Fatalf("NewFromToken(%q) = %v", s, err)
+		}
+		want := tok.Rand()
+		for range 10 {
+			if x, y := r.Uint64(), want.Uint64(); x != y {
+				t.Fatalf("NewFromToken(%q) produced %#x, want %#x", s, x, y)
+			}
+		}
+	}
+
+	// A fixed token, so the typo is deterministically caught by the
+	// checksum.
+	s := SeedToken{Seed: [32]byte{1, 2, 3}}.String()
+	typo := []byte(s)
+	typo[10] = map[bool]byte{true: 'b', false: 'a'}[typo[10] == 'a']
+	for _, bad := range []string{"", "not a token", s[:54], s + "a", string(typo)} {
+		if _, err := ParseSeedToken(bad); err == nil {
+			t.Errorf("ParseSeedToken(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package rnd
+
+import (
+	"context"
+	"iter"
+	"math"
+	"sync"
+	"time"
+)
+
+// PoissonArrivals returns the arrival distribution of a Poisson process, for
+// use as TrafficGen.Arrival. Events are independent of each other.
+func PoissonArrivals() Distribution {
+	return ExponentialDist{Rate: 1}
+}
+
+// ConstantArrivals returns an arrival distribution for TrafficGen.Arrival,
+// which fires at a constant rate, with every gap randomly shortened or
+// lengthened by up to the fraction jitter. It panics if jitter is not in
+// [0,1].
+func ConstantArrivals(jitter float64) Distribution {
+	if !(jitter >= 0 && jitter <= 1) {
+		panic("invalid jitter for ConstantArrivals")
+	}
+	return UniformDist{Min: 1 - jitter, Max: 1 + jitter}
+}
+
+// LogNormalArrivals returns an arrival distribution for TrafficGen.Arrival,
+// with log-normally distributed gaps, as is typical for the think times of
+// human users. sigma is the standard deviation of the logarithm of the gaps.
+// It panics if sigma is negative or NaN.
+func LogNormalArrivals(sigma float64) Distribution {
+	if !(sigma >= 0) {
+		panic("invalid sigma for LogNormalArrivals")
+	}
+	// Shift the mean of the logarithm, so the gaps have mean 1.
+	mu := -sigma * sigma / 2
+	return DistFunc(func(g Generator) float64 {
+		return math.Exp(mu + sigma*g.NormFloat64())
+	})
+}
+
+// Stage is a phase of the schedule of a TrafficGen. During a stage, the rate
+// changes linearly to Rate, from the rate at the end of the previous stage.
+// A stage with the same rate as the previous one holds the rate constant.
+type Stage struct {
+	// Rate is the rate at the end of the stage, in events per second.
+	Rate float64
+	// Duration is the length of the stage.
+	Duration time.Duration
+}
+
+// TrafficGen generates the times at which an open-loop load test fires
+// requests. In an open loop, fire times do not depend on how long earlier
+// requests take, so a slow system under test does not slow down the load.
+//
+// The fire times follow an arrival process with a rate, which changes over
+// time according to a schedule of stages. The generator ends after the last
+// stage. For example, to ramp up to 100 requests per second over a minute and
+// hold that rate for ten minutes:
+//
+//	g := &rnd.TrafficGen{
+//		Stages: []rnd.Stage{
+//			{Rate: 100, Duration: time.Minute},
+//			{Rate: 100, Duration: 10 * time.Minute},
+//		},
+//	}
+//
+// A TrafficGen must not be modified while it is used.
+type TrafficGen struct {
+	// Arrival is the distribution of the gaps between fire times, in units
+	// of the mean gap at the current rate. It must only return non-negative
+	// values and should have mean 1, like the distributions returned by
+	// PoissonArrivals, ConstantArrivals and LogNormalArrivals. If it is nil,
+	// PoissonArrivals is used.
+	Arrival Distribution
+	// Start is the rate at the start of the first stage, in events per
+	// second.
+	Start float64
+	// Stages is the schedule of rates.
+	Stages []Stage
+}
+
+// Offsets returns an iterator over the fire times of g, as offsets from the
+// start of the schedule. It does not wait, so it can be used to precompute a
+// schedule or to drive a simulation.
+//
+// Offsets panics if any rate of g is negative, NaN or infinite, or any
+// stage has a negative duration. The iterator panics if g.Arrival returns a
+// negative value.
+func (g *TrafficGen) Offsets() iter.Seq[time.Duration] {
+	g.check()
+	arrival := g.Arrival
+	if arrival == nil {
+		arrival = PoissonArrivals()
+	}
+	return func(yield func(time.Duration) bool) {
+		// Gaps are drawn in operational time, in which the rate is 1. They
+		// are mapped to real time by inverting the cumulative rate of the
+		// schedule. For Poisson arrivals, that yields a non-homogeneous
+		// Poisson process with the rate of the schedule.
+		var (
+			i     int           // current stage
+			begin time.Duration // start of the current stage
+			s     float64       // seconds since begin
+			done  float64       // cumulative rate of stage i up to s
+		)
+		r0 := g.Start
+		for {
+			tau := arrival.Sample(Global())
+			if !(tau >= 0) {
+				panic("negative gap in TrafficGen.Arrival")
+			}
+			for {
+				if i == len(g.Stages) {
+					return
+				}
+				st := g.Stages[i]
+				d := st.Duration.Seconds()
+				total := (r0 + st.Rate) / 2 * d
+				if total > 0 && done+tau <= total {
+					done += tau
+					s = min(stageTime(r0, st.Rate, d, done), d)
+					break
+				}
+				tau -= total - done
+				begin += st.Duration
+				r0 = st.Rate
+				i, s, done = i+1, 0, 0
+			}
+			if !yield(begin + seconds(s)) {
+				return
+			}
+		}
+	}
+}
+
+// stageTime returns the time s in [0,d], at which the integral of the rate,
+// changing linearly from r0 to r1 over d seconds, reaches m.
+func stageTime(r0, r1, d, m float64) float64 {
+	// Solve a·s² + r0·s = m, with a = (r1-r0)/(2d), in a form which is stable
+	// for a ≈ 0.
+	if m == 0 {
+		// The formula below is 0/0 for r0 == 0.
+		return 0
+	}
+	a := (r1 - r0) / (2 * d)
+	return 2 * m / (r0 + math.Sqrt(max(0, r0*r0+4*a*m)))
+}
+
+// check panics if the schedule of g is invalid.
+func (g *TrafficGen) check() {
+	valid := func(r float64) bool {
+		return r >= 0 && !math.IsInf(r, 1)
+	}
+	if !valid(g.Start) {
+		panic("invalid rate in TrafficGen")
+	}
+	for _, st := range g.Stages {
+		if !valid(st.Rate) || st.Duration < 0 {
+			panic("invalid stage in TrafficGen")
+		}
+	}
+}
+
+// Chan starts g and returns a channel, on which the scheduled fire times are
+// sent as they arrive. The channel is closed after the last stage or when ctx
+// is done.
+//
+// The channel is unbuffered and the times are scheduled from the start, so an
+// event received late still carries the time it was due. Measuring latency
+// from that time avoids the coordinated omission problem of load tests, which
+// only measure from the time requests were actually sent.
+func (g *TrafficGen) Chan(ctx context.Context) <-chan time.Time {
+	next, stop := iter.Pull(g.Offsets())
+	c := make(chan time.Time)
+	go func() {
+		defer close(c)
+		defer stop()
+		start := time.Now()
+		// As of Go 1.23, Reset discards any pending tick, so the timer
+		// does not need to be drained.
+		timer := time.NewTimer(math.MaxInt64)
+		defer timer.Stop()
+		for {
+			off, ok := next()
+			if !ok {
+				return
+			}
+			due := start.Add(off)
+			timer.Reset(time.Until(due))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case c <- due:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// Run calls fn in a new goroutine at every fire time of g, passing the time
+// the call was due. It returns after the last stage, once all calls have
+// returned. If ctx is done before, Run stops firing, waits for running calls
+// and returns ctx.Err().
+func (g *TrafficGen) Run(ctx context.Context, fn func(due time.Time)) error {
+	var wg sync.WaitGroup
+	for due := range g.Chan(ctx) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(due)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,110 @@
+package rnd
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countOffsets returns the number of fire times of g in [lo,hi).
+func countOffsets(g *TrafficGen, lo, hi time.Duration) int {
+	var n int
+	for off := range g.Offsets() {
+		if off >= lo && off < hi {
+			n++
+		}
+	}
+	return n
+}
+
+func TestTrafficGenOffsets(t *testing.T) {
+	for _, arrival := range []Distribution{nil, ConstantArrivals(0.5), LogNormalArrivals(1)} {
+		g := &TrafficGen{
+			Arrival: arrival,
+			Stages: []Stage{
+				{Rate: 1000, Duration: 10 * time.Second},
+				{Rate: 1000, Duration: 10 * time.Second},
+				{Rate: 0, Duration: 10 * time.Second},
+			},
+		}
+		var last time.Duration
+		for off := range g.Offsets() {
+			if off < last || off > 30*time.Second {
+				t.Fatalf("Offsets yielded %v after %v", off, last)
+			}
+			last = off
+		}
+		// The expected numbers of events are the integrals of the rate.
+		for _, c := range []struct {
+			lo, hi time.Duration
+			want   float64
+		}{
+			{0, 5 * time.Second, 1250},
+			{5 * time.Second, 10 * time.Second, 3750},
+			{10 * time.Second, 20 * time.Second, 10000},
+			{25 * time.Second, 30 * time.Second, 1250},
+		} {
+			got := float64(countOffsets(g, c.lo, c.hi))
+			if math.Abs(got-c.want) > 6*math.Sqrt(c.want) {
+				t.Errorf("%v: %d events in [%v,%v), want about %v", arrival, int(got), c.lo, c.hi, c.want)
+			}
+		}
+	}
+
+	// Gaps of 0 are valid, even where the rate is 0.
+	g := &TrafficGen{
+		Arrival: DistFunc(func(Generator) float64 { return 0 }),
+		Stages:  []Stage{{Rate: 10, Duration: time.Second}},
+	}
+	var n int
+	for off := range g.Offsets() {
+		if off != 0 {
+			t.Fatalf("Offsets with zero gaps yielded %v, want 0", off)
+		}
+		if n++; n == 10 {
+			break
+		}
+	}
+
+	g = &TrafficGen{Start: 10, Stages: []Stage{{Rate: 10, Duration: 0}}}
+	for range g.Offsets() {
+		t.Fatalf("Offsets yielded event for empty schedule")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Offsets with negative rate did not panic")
+		}
+	}()
+	(&TrafficGen{Stages: []Stage{{Rate: -1, Duration: time.Second}}}).Offsets()
+}
+
+func TestTrafficGenRun(t *testing.T) {
+	g := &TrafficGen{
+		Arrival: ConstantArrivals(0),
+		Start:   200,
+		Stages:  []Stage{{Rate: 200, Duration: 100 * time.Millisecond}},
+	}
+	start := time.Now()
+	var calls atomic.Int64
+	err := g.Run(context.Background(), func(due time.Time) {
+		if due.Before(start) || due.After(start.Add(time.Second)) {
+			t.Errorf("fn called with due time %v, start was %v", due, start)
+		}
+		calls.Add(1)
+	})
+	if err != nil {
+		t.Errorf("Run returned %v", err)
+	}
+	if n := calls.Load(); n < 18 || n > 20 {
+		t.Errorf("Run called fn %d times, want 19 or 20", n)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	g.Stages[0].Duration = time.Hour
+	if err := g.Run(ctx, func(time.Time) {}); err != context.DeadlineExceeded {
+		t.Errorf("Run with expiring context returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
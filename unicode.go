@@ -0,0 +1,146 @@
+package rnd
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// runeSet is a set of valid runes, represented as a sorted list of disjoint
+// intervals, for uniform sampling.
+type runeSet struct {
+	ivs []runeInterval
+	// cum[i] is the number of runes in ivs[:i+1].
+	cum []int64
+}
+
+type runeInterval struct {
+	lo, hi rune
+}
+
+// newRuneSet returns the set of runes covered by any of tables, excluding
+// surrogates. If no tables are given, it contains all valid runes.
+func newRuneSet(tables []*unicode.RangeTable) *runeSet {
+	var ivs []runeInterval
+	add := func(lo, hi, stride uint32) {
+		if stride == 1 {
+			ivs = append(ivs, runeInterval{rune(lo), rune(hi)})
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			ivs = append(ivs, runeInterval{rune(r), rune(r)})
+		}
+	}
+	for _, t := range tables {
+		for _, r := range t.R16 {
+			add(uint32(r.Lo), uint32(r.Hi), uint32(r.Stride))
+		}
+		for _, r := range t.R32 {
+			add(r.Lo, r.Hi, r.Stride)
+		}
+	}
+	if len(tables) == 0 {
+		add(0, unicode.MaxRune, 1)
+	}
+	return newRuneSetFromIntervals(ivs)
+}
+
+// newRuneSetFromIntervals returns the set of runes covered by any of ivs,
+// excluding surrogates. It modifies ivs.
+func newRuneSetFromIntervals(ivs []runeInterval) *runeSet {
+	slices.SortFunc(ivs, func(a, b runeInterval) int {
+		return int(a.lo - b.lo)
+	})
+
+	s := new(runeSet)
+	push := func(iv runeInterval) {
+		if iv.lo > iv.hi {
+			return
+		}
+		if n := len(s.ivs); n > 0 && iv.lo <= s.ivs[n-1].hi+1 {
+			s.ivs[n-1].hi = max(s.ivs[n-1].hi, iv.hi)
+			return
+		}
+		s.ivs = append(s.ivs, iv)
+	}
+	for _, iv := range ivs {
+		// Surrogates are not valid runes.
+		push(runeInterval{iv.lo, min(iv.hi, surrogateMin-1)})
+		push(runeInterval{max(iv.lo, surrogateMax+1), iv.hi})
+	}
+	var n int64
+	for _, iv := range s.ivs {
+		n += int64(iv.hi-iv.lo) + 1
+		s.cum = append(s.cum, n)
+	}
+	return s
+}
+
+const (
+	surrogateMin = 0xd800
+	surrogateMax = 0xdfff
+)
+
+// pick returns a uniformly chosen rune from s. It panics, if s is empty.
+func (s *runeSet) pick() rune {
+	if len(s.cum) == 0 {
+		panic("no runes to choose from")
+	}
+	i := Int63n(s.cum[len(s.cum)-1])
+	k := sort.Search(len(s.cum), func(k int) bool { return s.cum[k] > i })
+	if k > 0 {
+		i -= s.cum[k-1]
+	}
+	return s.ivs[k].lo + rune(i)
+}
+
+// runeSets caches runeSets for single tables, by table.
+var runeSets sync.Map // map[*unicode.RangeTable]*runeSet
+
+// runeSetFor is like newRuneSet, but caches the result, if at most one table
+// is given.
+func runeSetFor(tables []*unicode.RangeTable) *runeSet {
+	if len(tables) > 1 {
+		return newRuneSet(tables)
+	}
+	var key *unicode.RangeTable
+	if len(tables) == 1 {
+		key = tables[0]
+	}
+	if s, ok := runeSets.Load(key); ok {
+		return s.(*runeSet)
+	}
+	s, _ := runeSets.LoadOrStore(key, newRuneSet(tables))
+	return s.(*runeSet)
+}
+
+// Rune returns a random rune. Every rune covered by any of tables is chosen
+// with the same probability. Surrogates are never chosen. If no tables are
+// given, all valid runes are used.
+//
+// The runes covered by a single table are cached, so Rune is cheap to call
+// repeatedly with the same table. Tables must not be modified after passing
+// them to Rune.
+//
+// Rune panics, if tables do not cover any valid runes.
+func Rune(tables ...*unicode.RangeTable) rune {
+	return runeSetFor(tables).pick()
+}
+
+// UTF8String returns a random, valid UTF-8 string of nRunes runes. Every rune
+// covered by any of ranges is chosen with the same probability. Surrogates are
+// never chosen. If no ranges are given, all valid runes are used.
+//
+// UTF8String panics, if ranges do not cover any valid runes.
+func UTF8String(nRunes int, ranges ...*unicode.RangeTable) string {
+	s := runeSetFor(ranges)
+	var sb strings.Builder
+	sb.Grow(nRunes * utf8.UTFMax)
+	for i := 0; i < nRunes; i++ {
+		sb.WriteRune(s.pick())
+	}
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package rnd
+
+import (
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestUTF8String(t *testing.T) {
+	tcs := [][]*unicode.RangeTable{
+		nil,
+		{unicode.Latin},
+		{unicode.Han, unicode.Hiragana},
+		{unicode.Lu},
+		{unicode.Cs, unicode.Greek},
+		{unicode.L, unicode.Latin},
+	}
+	for _, tables := range tcs {
+		s := UTF8String(1000, tables...)
+		if !utf8.ValidString(s) || utf8.RuneCountInString(s) != 1000 {
+			t.Fatalf("UTF8String(1000, %v) returned %d runes, valid = %v", tables, utf8.RuneCountInString(s), utf8.ValidString(s))
+		}
+		for _, r := range s {
+			if len(tables) > 0 && !unicode.In(r, tables...) {
+				t.Fatalf("UTF8String(1000, %v) contains %U, which is not in any table", tables, r)
+			}
+		}
+	}
+}
+
+func TestRuneSet(t *testing.T) {
+	s := newRuneSet([]*unicode.RangeTable{unicode.L, unicode.Latin})
+	var want int64
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if unicode.In(r, unicode.L, unicode.Latin) {
+			want++
+		}
+	}
+	if got := s.cum[len(s.cum)-1]; got != want {
+		t.Errorf("set of letters has %d runes, want %d", got, want)
+	}
+	all := newRuneSet(nil)
+	if got, want := all.cum[len(all.cum)-1], int64(unicode.MaxRune+1-(surrogateMax-surrogateMin+1)); got != want {
+		t.Errorf("set of all runes has %d runes, want %d", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("UTF8String(1, unicode.Cs) did not panic")
+		}
+	}()
+	UTF8String(1, unicode.Cs)
+}
+
+func TestRune(t *testing.T) {
+	for _, tables := range [][]*unicode.RangeTable{nil, {unicode.Greek}, {unicode.Greek}, {unicode.Nd, unicode.Cyrillic}} {
+		for i := 0; i < 1000; i++ {
+			r := Rune(tables...)
+			if !utf8.ValidRune(r) || (len(tables) > 0 && !unicode.In(r, tables...)) {
+				t.Fatalf("Rune(%v) = %U", tables, r)
+			}
+		}
+	}
+}
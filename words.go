@@ -0,0 +1,72 @@
+package rnd
+
+import (
+	"embed"
+	"strings"
+	"sync"
+)
+
+//go:embed words
+var wordsFS embed.FS
+
+// wordList returns a function returning the words from the given file in the
+// words directory, one per line.
+func wordList(name string) func() []string {
+	return sync.OnceValue(func() []string {
+		b, err := wordsFS.ReadFile("words/" + name)
+		if err != nil {
+			panic(err)
+		}
+		return strings.Fields(string(b))
+	})
+}
+
+var (
+	passphraseWords = wordList("passphrase.txt")
+	adjectives      = wordList("adjectives.txt")
+	animals         = wordList("animals.txt")
+)
+
+// Passphrase returns nWords random words from an embedded list of 1126 short,
+// common English words, separated by sep. Every word adds about 10 bits of
+// randomness. It is meant for memorable identifiers, like names of test
+// environments.
+func Passphrase(nWords int, sep string) string {
+	words := passphraseWords()
+	var sb strings.Builder
+	for i := 0; i < nWords; i++ {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(words[Intn(len(words))])
+	}
+	return sb.String()
+}
+
+// Petname returns a human-friendly name of nWords words, separated by
+// hyphens, like "brave-crimson-otter". The last word is an animal, the others
+// are adjectives, with no adjective repeated directly. It panics if
+// nWords < 1.
+//
+// Petnames are meant for naming things like test clusters or ephemeral
+// resources. With three words, there are about three million of them, so
+// collisions are likely if many names are generated.
+func Petname(nWords int) string {
+	if nWords < 1 {
+		panic("invalid argument to Petname")
+	}
+	adj, ani := adjectives(), animals()
+	var sb strings.Builder
+	prev := -1
+	for i := 0; i < nWords-1; i++ {
+		j := Intn(len(adj))
+		for j == prev {
+			j = Intn(len(adj))
+		}
+		prev = j
+		sb.WriteString(adj[j])
+		sb.WriteByte('-')
+	}
+	sb.WriteString(ani[Intn(len(ani))])
+	return sb.String()
+}
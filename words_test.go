@@ -0,0 +1,40 @@
+package rnd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassphrase(t *testing.T) {
+	if got := len(passphraseWords()); got != 1126 {
+		t.Errorf("passphrase word list has %d words, want 1126", got)
+	}
+	if p := Passphrase(0, "-"); p != "" {
+		t.Errorf("Passphrase(0, %q) = %q, want %q", "-", p, "")
+	}
+	p := Passphrase(4, "-")
+	if n := len(strings.Split(p, "-")); n != 4 {
+		t.Errorf("Passphrase(4, %q) = %q, has %d words, want 4", "-", p, n)
+	}
+}
+
+func TestPetname(t *testing.T) {
+	isAnimal := make(map[string]bool)
+	for _, w := range animals() {
+		isAnimal[w] = true
+	}
+	for _, n := range []int{1, 2, 3, 10} {
+		words := strings.Split(Petname(n), "-")
+		if len(words) != n {
+			t.Fatalf("Petname(%d) has %d words", n, len(words))
+		}
+		if !isAnimal[words[n-1]] {
+			t.Errorf("Petname(%d) ends with %q, which is not an animal", n, words[n-1])
+		}
+		for i := 1; i < n-1; i++ {
+			if words[i] == words[i-1] {
+				t.Errorf("Petname(%d) repeats adjective %q", n, words[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package rnd
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// The normal and exponential distributions are sampled using the Ziggurat
+// algorithm, as described by Marsaglia and Tsang, "The Ziggurat Method for
+// Generating Random Variables", 2000. The layers are computed at
+// initialization, from the published constants.
+
+const (
+	// zigNormR is the start of the tail of the normal distribution and
+	// zigNormV the area of every layer, for 128 layers.
+	zigNormR = 3.442619855899
+	zigNormV = 9.91256303526217e-3
+	// zigExpR is the start of the tail of the exponential distribution and
+	// zigExpV the area of every layer, for 256 layers.
+	zigExpR = 7.69711747013104972
+	zigExpV = 3.949659822581572e-3
+)
+
+var (
+	// zigNormX[i] is the width of layer i, zigNormF[i] the density at
+	// zigNormX[i]. Layer 0 is the base, including the tail, so its width is
+	// virtual.
+	zigNormX, zigNormF = zigTables(128, zigNormR, zigNormV,
+		func(x float64) float64 { return math.Exp(-x * x / 2) },
+		func(y float64) float64 { return math.Sqrt(-2 * math.Log(y)) })
+	zigNormK, zigNormW = zigFast(zigNormX, 52)
+	// Arrays let the compiler elide bounds checks in the fast path.
+	zigNormKA, zigNormWA = [128]uint64(zigNormK), [128]float64(zigNormW)
+
+	zigExpX, zigExpF = zigTables(256, zigExpR, zigExpV,
+		func(x float64) float64 { return math.Exp(-x) },
+		func(y float64) float64 { return -math.Log(y) })
+	zigExpK, zigExpW   = zigFast(zigExpX, 53)
+	zigExpKA, zigExpWA = [256]uint64(zigExpK), [256]float64(zigExpW)
+)
+
+// zigFast computes the tables for the fast path: an integer m with
+// |m| < 2^bits is inside the rectangle fully below the density of layer i,
+// if |m| < k[i]. The corresponding value is then m·w[i].
+func zigFast(x []float64, bits int) (k []uint64, w []float64) {
+	k = make([]uint64, len(x)-1)
+	w = make([]float64, len(x)-1)
+	for i := range k {
+		k[i] = uint64(math.Ldexp(x[i+1]/x[i], bits))
+		w[i] = math.Ldexp(x[i], -bits)
+	}
+	return k, w
+}
+
+// zigTables computes the layers of a Ziggurat with n layers, tail start r and
+// layer area v, for the density f with inverse finv.
+func zigTables(n int, r, v float64, f, finv func(float64) float64) (x, fx []float64) {
+	x = make([]float64, n+1)
+	fx = make([]float64, n+1)
+	x[0], x[1] = v/f(r), r
+	for i := 2; i < n; i++ {
+		x[i] = finv(v/x[i-1] + f(x[i-1]))
+	}
+	x[n] = 0
+	// fx[0] is never used, as samples from the base layer outside of
+	// [0,r) are taken from the tail.
+	for i := range x {
+		fx[i] = f(x[i])
+	}
+	return x, fx
+}
+
+// uniform returns a uniform value in [0,1), using a value from src.
+func uniform(src rand.Source) float64 {
+	return float64(src.Uint64()>>11) / (1 << 53)
+}
+
+// zigNorm returns a standard normal value, using values from src.
+func zigNorm(src rand.Source) float64 {
+	for {
+		j := src.Uint64()
+		// m is a signed 53-bit integer. Using it directly, instead of a
+		// separate sign bit, avoids an unpredictable branch.
+		i, m := j&127, int64(j)>>11
+		x := float64(m) * zigNormWA[i]
+		if s := m >> 63; uint64((m^s)-s) < zigNormKA[i] {
+			// x is inside the rectangle fully below the density.
+			return x
+		}
+		if i == 0 {
+			// Sample from the tail, see Marsaglia, "Generating a variable
+			// from the tail of the normal distribution", 1964.
+			for {
+				a := -math.Log(1-uniform(src)) / zigNormR
+				b := -math.Log(1 - uniform(src))
+				if 2*b > a*a {
+					if x < 0 {
+						return -zigNormR - a
+					}
+					return zigNormR + a
+				}
+			}
+		}
+		if y := zigNormF[i+1] + uniform(src)*(zigNormF[i]-zigNormF[i+1]); y < math.Exp(-x*x/2) {
+			return x
+		}
+	}
+}
+
+// zigExp returns an exponentially distributed value with rate 1, using
+// values from src.
+func zigExp(src rand.Source) float64 {
+	for {
+		j := src.Uint64()
+		i, m := j&255, j>>11
+		x := float64(m) * zigExpWA[i]
+		if m < zigExpKA[i] {
+			return x
+		}
+		if i == 0 {
+			// The exponential distribution is memoryless, so the tail is
+			// a shifted exponential distribution.
+			return zigExpR + zigExp(src)
+		}
+		if y := zigExpF[i] + uniform(src)*(zigExpF[i+1]-zigExpF[i]); y < math.Exp(-x) {
+			return x
+		}
+	}
+}
+
+// FillNormFloat64 fills dst with standard normal values, as returned by
+// NormFloat64. It synchronizes only once, so it is faster than repeatedly
+// calling NormFloat64.
+func FillNormFloat64(dst []float64) {
+	defer reseed(len(dst))
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	for i := range dst {
+		dst[i] = zigNorm(src.src)
+	}
+}
+
+// FillExpFloat64 fills dst with exponentially distributed values, as returned
+// by ExpFloat64. It synchronizes only once, so it is faster than repeatedly
+// calling ExpFloat64.
+func FillExpFloat64(dst []float64) {
+	defer reseed(len(dst))
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	for i := range dst {
+		dst[i] = zigExp(src.src)
+	}
+}
@@ -0,0 +1,100 @@
+package rnd
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// checkCDF checks that the empirical distribution of vs matches cdf, at a
+// couple of points.
+func checkCDF(t *testing.T, name string, vs []float64, cdf func(float64) float64, points []float64) {
+	t.Helper()
+	for _, x := range points {
+		n := 0
+		for _, v := range vs {
+			if v <= x {
+				n++
+			}
+		}
+		got, want := float64(n)/float64(len(vs)), cdf(x)
+		// The standard deviation is at most 0.5/√len(vs).
+		if math.Abs(got-want) > 2.5/math.Sqrt(float64(len(vs))) {
+			t.Errorf("%s: P(X ≤ %v) = %v, want %v", name, x, got, want)
+		}
+	}
+}
+
+func TestZiggurat(t *testing.T) {
+	const n = 100000
+	normCDF := func(x float64) float64 { return (1 + math.Erf(x/math.Sqrt2)) / 2 }
+	expCDF := func(x float64) float64 { return 1 - math.Exp(-x) }
+	normPoints := []float64{-3.5, -2, -1, -0.3, 0, 0.3, 1, 2, 3.5}
+	expPoints := []float64{0.01, 0.1, 0.5, 1, 2, 5, 7.8}
+
+	vs := make([]float64, n)
+	FillNormFloat64(vs)
+	checkCDF(t, "FillNormFloat64", vs, normCDF, normPoints)
+	FillExpFloat64(vs)
+	checkCDF(t, "FillExpFloat64", vs, expCDF, expPoints)
+
+	r := New()
+	for i := range vs {
+		vs[i] = r.NormFloat64()
+	}
+	checkCDF(t, "Rand.NormFloat64", vs, normCDF, normPoints)
+	for i := range vs {
+		vs[i] = ExpFloat64()
+		if vs[i] <= 0 {
+			t.Fatalf("ExpFloat64() = %v", vs[i])
+		}
+	}
+	checkCDF(t, "ExpFloat64", vs, expCDF, expPoints)
+}
+
+func TestZigguratTables(t *testing.T) {
+	// The top layers must end at the mode of the density.
+	for name, x := range map[string][]float64{"normal": zigNormX, "exponential": zigExpX} {
+		if n := len(x); x[n-1] != 0 || !(x[n-2] > 0) {
+			t.Errorf("%s: top layers are %v", name, x[n-3:])
+		}
+	}
+}
+
+func BenchmarkNormFloat64(b *testing.B) {
+	b.Run("rnd", func(b *testing.B) {
+		for range b.N {
+			NormFloat64()
+		}
+	})
+	b.Run("Fill", func(b *testing.B) {
+		dst := make([]float64, 1024)
+		for i := 0; i < b.N; i += len(dst) {
+			FillNormFloat64(dst)
+		}
+	})
+	b.Run("math/rand/v2", func(b *testing.B) {
+		for range b.N {
+			rand.NormFloat64()
+		}
+	})
+}
+
+func BenchmarkExpFloat64(b *testing.B) {
+	b.Run("rnd", func(b *testing.B) {
+		for range b.N {
+			ExpFloat64()
+		}
+	})
+	b.Run("Fill", func(b *testing.B) {
+		dst := make([]float64, 1024)
+		for i := 0; i < b.N; i += len(dst) {
+			FillExpFloat64(dst)
+		}
+	})
+	b.Run("math/rand/v2", func(b *testing.B) {
+		for range b.N {
+			rand.ExpFloat64()
+		}
+	})
+}